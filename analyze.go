@@ -0,0 +1,117 @@
+package lexorank
+
+import (
+	"math"
+	"sort"
+)
+
+type RankAnalysis struct {
+	Count     int
+	Nil       int
+	MaxLength int
+	Lengths   map[int]int
+	MaxScale  int
+	MinGap    *LexoDecimal
+	Remaining int
+	Buckets   map[string]*RankAnalysis
+}
+
+type RebalancePolicy func(*RankAnalysis) bool
+
+// MinRemainingPolicy asks for a rebalance once fewer than n Between operations
+// fit into the tightest gap.
+func MinRemainingPolicy(n int) RebalancePolicy {
+	return func(a *RankAnalysis) bool {
+		return a.Remaining < n
+	}
+}
+
+// Analyze reports how close ranks are to exceeding maxLength. Remaining is an
+// estimate of how many times Between can split the tightest gap in a row. Nil
+// ranks are counted in Nil and otherwise left out.
+func Analyze(ranks []*LexoRank, maxLength int) *RankAnalysis {
+	result := newRankAnalysis(maxLength)
+	byBucket := make(map[string][]*LexoRank)
+	for _, rank := range ranks {
+		if rank == nil {
+			result.Nil++
+			continue
+		}
+		byBucket[rank.bucket.String()] = append(byBucket[rank.bucket.String()], rank)
+	}
+	result.Buckets = make(map[string]*RankAnalysis, len(byBucket))
+	for name, bucketRanks := range byBucket {
+		analysis := analyzeBucket(bucketRanks, maxLength)
+		result.Buckets[name] = analysis
+		result.Count += analysis.Count
+		for length, count := range analysis.Lengths {
+			result.Lengths[length] += count
+		}
+		if analysis.MaxScale > result.MaxScale {
+			result.MaxScale = analysis.MaxScale
+		}
+		if analysis.MinGap != nil && (result.MinGap == nil || analysis.MinGap.Compare(result.MinGap) < 0) {
+			result.MinGap = analysis.MinGap
+		}
+		if analysis.Remaining < result.Remaining {
+			result.Remaining = analysis.Remaining
+		}
+	}
+	return result
+}
+
+func (a *RankAnalysis) ShouldRebalance(policy RebalancePolicy) bool {
+	return policy(a)
+}
+
+func newRankAnalysis(maxLength int) *RankAnalysis {
+	return &RankAnalysis{
+		MaxLength: maxLength,
+		Lengths:   make(map[int]int),
+		Remaining: math.MaxInt,
+	}
+}
+
+func analyzeBucket(ranks []*LexoRank, maxLength int) *RankAnalysis {
+	result := newRankAnalysis(maxLength)
+	sorted := make([]*LexoRank, len(ranks))
+	copy(sorted, ranks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].decimal.Compare(sorted[j].decimal) < 0
+	})
+	result.Count = len(sorted)
+	scale := maxScale(sorted[0].bucket, maxLength)
+	for idx, rank := range sorted {
+		result.Lengths[len(rank.String())]++
+		if rank.decimal.GetScale() > result.MaxScale {
+			result.MaxScale = rank.decimal.GetScale()
+		}
+		low := minDecimal
+		if idx > 0 {
+			low = sorted[idx-1].decimal
+			gap := rank.decimal.Sub(low)
+			if result.MinGap == nil || gap.Compare(result.MinGap) < 0 {
+				result.MinGap = gap
+			}
+		}
+		if remaining := remainingSplits(low, rank.decimal, scale); remaining < result.Remaining {
+			result.Remaining = remaining
+		}
+	}
+	if remaining := remainingSplits(sorted[len(sorted)-1].decimal, maxDecimal, scale); remaining < result.Remaining {
+		result.Remaining = remaining
+	}
+	return result
+}
+
+func remainingSplits(low, high *LexoDecimal, scale int) int {
+	if scale < 0 || low.Compare(high) >= 0 {
+		return 0
+	}
+	slots := low.slots(high, scale)
+	if slots.sign <= 0 {
+		return 0
+	}
+	slots, _ = slots.Add(lexoIntegerOne(slots.GetSystem()))
+	return int(slots.log2())
+}
@@ -0,0 +1,34 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	got := Analyze(parseRanks(t, "0|100001:", "0|100000:", "0|100000:i", "1|i00000:"), 12)
+
+	assert.Equal(t, 4, got.Count)
+	assert.Equal(t, map[int]int{9: 3, 10: 1}, got.Lengths)
+	assert.Equal(t, 1, got.MaxScale)
+	assert.Equal(t, "0:i", got.MinGap.String())
+	assert.Equal(t, 14, got.Remaining)
+	assert.Len(t, got.Buckets, 2)
+	assert.Equal(t, 3, got.Buckets["0"].Count)
+	assert.Equal(t, 1, got.Buckets["1"].Count)
+	assert.True(t, got.ShouldRebalance(MinRemainingPolicy(15)))
+	assert.False(t, got.ShouldRebalance(MinRemainingPolicy(14)))
+}
+
+func TestAnalyze_Nil(t *testing.T) {
+	got := Analyze([]*LexoRank{nil, MidLexoRank, nil}, 12)
+	assert.Equal(t, 1, got.Count)
+	assert.Equal(t, 2, got.Nil)
+	assert.Equal(t, 1, got.Buckets["0"].Count)
+
+	empty := Analyze([]*LexoRank{nil}, 12)
+	assert.Equal(t, 0, empty.Count)
+	assert.Equal(t, 1, empty.Nil)
+	assert.Empty(t, empty.Buckets)
+}
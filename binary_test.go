@@ -0,0 +1,67 @@
+package lexorank
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexoRank_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		rank string
+		want string
+	}{
+		{rank: "0|000000:", want: "0000000000"},
+		{rank: "1|zzzzzz:", want: "0181bf0fff"},
+		{rank: "0|hzzzzz:", want: "0040df87ff"},
+		{rank: "0|hzzzzz:i", want: "0040df87ff5b20"},
+		{rank: "2|000001:00i1", want: "020000000100120510"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rank, func(t *testing.T) {
+			rank, err := LexoRankParse(tt.rank)
+			assert.NoError(t, err)
+			data, err := rank.MarshalBinary()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(data))
+
+			var decoded LexoRank
+			assert.NoError(t, decoded.UnmarshalBinary(data))
+			assert.Equal(t, tt.rank, decoded.String())
+		})
+	}
+}
+
+func TestLexoRank_UnmarshalBinary_Errors(t *testing.T) {
+	for _, data := range []string{"", "00000000", "000000000000", "0000000000ffff", "0000000000b6400000", "00ffffffff"} {
+		raw, _ := hex.DecodeString(data)
+		var rank LexoRank
+		assert.Errorf(t, rank.UnmarshalBinary(raw), "UnmarshalBinary(%s)", data)
+	}
+}
+
+func TestLexoRank_MarshalBinary_Order(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ranks := []*LexoRank{MinLexoRank, MaxLexoRank, MinLexoRank.InBucket(LexoRankBucket1), MaxLexoRank.InBucket(LexoRankBucket2)}
+	for idx := 0; idx < 500; idx++ {
+		left, right := ranks[rnd.Intn(len(ranks))], ranks[rnd.Intn(len(ranks))]
+		if left.bucket.Equals(right.bucket) && left.Compare(right) != 0 {
+			rank, err := left.Between(right)
+			assert.NoError(t, err)
+			ranks = append(ranks, rank)
+		}
+	}
+	encoded := make([][]byte, len(ranks))
+	for idx, rank := range ranks {
+		data, err := rank.MarshalBinary()
+		assert.NoError(t, err)
+		encoded[idx] = data
+	}
+	for idx := 0; idx < 2000; idx++ {
+		left, right := rnd.Intn(len(ranks)), rnd.Intn(len(ranks))
+		assert.Equalf(t, ranks[left].Compare(ranks[right]), bytes.Compare(encoded[left], encoded[right]), "%s %s", ranks[left], ranks[right])
+	}
+}
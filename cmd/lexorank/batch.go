@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+type batchRow struct {
+	line     int
+	id       string
+	rawID    json.RawMessage
+	value    string
+	position int64
+	rank     *lexorank.LexoRank
+	valid    bool
+}
+
+type jsonRow struct {
+	ID       json.RawMessage `json:"id"`
+	Position *int64          `json:"position,omitempty"`
+	Rank     *string         `json:"rank,omitempty"`
+}
+
+func runRebalance(args []string, in io.Reader, out *output) error {
+	flags := newFlagSet("rebalance")
+	bucketName := bucketFlag(flags)
+	format := flags.String("format", "csv", "input and output format: csv or jsonl")
+	key := flags.String("key", "rank", "existing order column: rank or position")
+	header := flags.Bool("header", true, "csv input starts with a header row")
+	reportPath := flags.String("report", "", "write invalid and duplicate rows to this file instead of stderr")
+	sorted := flags.Bool("sorted", false, "FILE is already sorted by key: stream it in two passes instead of loading it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *key != "rank" && *key != "position" {
+		return fmt.Errorf("unknown key: %q", *key)
+	}
+	if *format != "csv" && *format != "jsonl" {
+		return fmt.Errorf("unknown format: %q", *format)
+	}
+	if *sorted && flags.NArg() == 0 {
+		return errors.New("-sorted needs a FILE argument, which is read twice")
+	}
+	bucket, err := parseBucket(*bucketName)
+	if err != nil {
+		return err
+	}
+	report := out.errw
+	if *reportPath != "" {
+		file, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		report = file
+	}
+
+	if *sorted {
+		n, err := countBatchRows(flags.Arg(0), *format, *key, *header)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		found, err := streamBatchRows(out.w, report, newBatchReader(file, *format, *key, *header), n, bucket, *format, *key)
+		if found {
+			out.failed = true
+		}
+		return err
+	}
+
+	if flags.NArg() > 0 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+	rows, err := readBatchRows(newBatchReader(in, *format, *key, *header))
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		parseBatchRow(row, *key)
+	}
+	sortBatchRows(rows, *key)
+	if reportBatchRows(report, rows, *key) {
+		out.failed = true
+	}
+	return writeBatchRows(out.w, rows, bucket, *format)
+}
+
+// batchReader returns the next input row, or io.EOF after the last one.
+type batchReader func() (*batchRow, error)
+
+func newBatchReader(in io.Reader, format, key string, header bool) batchReader {
+	if format == "jsonl" {
+		return jsonlReader(in, key)
+	}
+	return csvReader(in, header)
+}
+
+func readBatchRows(next batchReader) ([]*batchRow, error) {
+	var rows []*batchRow
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func countBatchRows(path, format, key string, header bool) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	next := newBatchReader(file, format, key, header)
+	for n := 0; ; n++ {
+		if _, err := next(); errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func csvReader(in io.Reader, header bool) batchReader {
+	reader := csv.NewReader(bufio.NewReader(in))
+	reader.FieldsPerRecord = 2
+	reader.ReuseRecord = true
+	line := 0
+	return func() (*batchRow, error) {
+		for {
+			line++
+			record, err := reader.Read()
+			if err != nil {
+				return nil, err
+			}
+			if header && line == 1 {
+				continue
+			}
+			return &batchRow{line: line, id: record[0], value: record[1]}, nil
+		}
+	}
+}
+
+// jsonlReader reads the field named by key as the row value, so a row that
+// carries both a position and a rank is ordered by the one asked for.
+func jsonlReader(in io.Reader, key string) batchReader {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 1<<20)
+	line := 0
+	return func() (*batchRow, error) {
+		for scanner.Scan() {
+			line++
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+			var raw jsonRow
+			if err := json.Unmarshal(scanner.Bytes(), &raw); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			row := &batchRow{line: line, id: string(raw.ID), rawID: raw.ID}
+			switch {
+			case key == "rank" && raw.Rank != nil:
+				row.value = *raw.Rank
+			case key == "position" && raw.Position != nil:
+				row.value = strconv.FormatInt(*raw.Position, 10)
+			}
+			return row, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+func parseBatchRow(row *batchRow, key string) {
+	var err error
+	if key == "position" {
+		row.position, err = strconv.ParseInt(row.value, 10, 64)
+	} else {
+		row.rank, err = lexorank.LexoRankParseStrict(row.value)
+	}
+	row.valid = err == nil
+}
+
+func compareBatchRows(left, right *batchRow, key string) int {
+	if key == "rank" {
+		return left.rank.Compare(right.rank)
+	}
+	switch {
+	case left.position < right.position:
+		return -1
+	case left.position > right.position:
+		return 1
+	}
+	return 0
+}
+
+func sortBatchRows(rows []*batchRow, key string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].valid != rows[j].valid {
+			return rows[i].valid
+		}
+		return rows[i].valid && compareBatchRows(rows[i], rows[j], key) < 0
+	})
+}
+
+func reportBatchRows(w io.Writer, rows []*batchRow, key string) bool {
+	found := false
+	for idx, row := range rows {
+		switch {
+		case !row.valid:
+			fmt.Fprintf(w, "line %d: id %s: invalid %s %q\n", row.line, row.id, key, row.value)
+		case idx > 0 && rows[idx-1].valid && compareBatchRows(rows[idx-1], row, key) == 0:
+			fmt.Fprintf(w, "line %d: id %s: duplicate %s %q\n", row.line, row.id, key, row.value)
+		default:
+			continue
+		}
+		found = true
+	}
+	return found
+}
+
+func writeBatchRows(w io.Writer, rows []*batchRow, bucket *lexorank.LexoRankBucket, format string) error {
+	spread, err := newBucketSpread(bucket, len(rows))
+	if err != nil {
+		return err
+	}
+	writer := newBatchWriter(w, format)
+	for _, row := range rows {
+		rank, _ := spread.Next()
+		if err := writer.write(row, rank); err != nil {
+			return err
+		}
+	}
+	return writer.flush()
+}
+
+// streamBatchRows ranks n rows that arrive sorted by key and writes each rank
+// as it is produced. Only invalid rows are held back, to be written last as in
+// the sorting mode. A row below its predecessor stops the stream with an error.
+func streamBatchRows(w, report io.Writer, next batchReader, n int, bucket *lexorank.LexoRankBucket, format, key string) (bool, error) {
+	spread, err := newBucketSpread(bucket, n)
+	if err != nil {
+		return false, err
+	}
+	writer := newBatchWriter(w, format)
+	found := false
+	var prev *batchRow
+	var invalid []*batchRow
+	write := func(row *batchRow) error {
+		rank, ok := spread.Next()
+		if !ok {
+			return errors.New("input changed between passes")
+		}
+		return writer.write(row, rank)
+	}
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return found, err
+		}
+		parseBatchRow(row, key)
+		switch {
+		case !row.valid:
+			fmt.Fprintf(report, "line %d: id %s: invalid %s %q\n", row.line, row.id, key, row.value)
+			found = true
+			invalid = append(invalid, row)
+			continue
+		case prev != nil && compareBatchRows(prev, row, key) > 0:
+			return found, fmt.Errorf("line %d: id %s: %s %q below the previous row; input is not sorted", row.line, row.id, key, row.value)
+		case prev != nil && compareBatchRows(prev, row, key) == 0:
+			fmt.Fprintf(report, "line %d: id %s: duplicate %s %q\n", row.line, row.id, key, row.value)
+			found = true
+		}
+		prev = row
+		if err := write(row); err != nil {
+			return found, err
+		}
+	}
+	for _, row := range invalid {
+		if err := write(row); err != nil {
+			return found, err
+		}
+	}
+	return found, writer.flush()
+}
+
+func newBucketSpread(bucket *lexorank.LexoRankBucket, n int) (*lexorank.LexoRankSpread, error) {
+	return lexorank.NewLexoRankSpread(lexorank.MinLexoRank.InBucket(bucket), lexorank.MaxLexoRank.InBucket(bucket), n)
+}
+
+type batchWriter struct {
+	buffered *bufio.Writer
+	csv      *csv.Writer
+	format   string
+}
+
+func newBatchWriter(w io.Writer, format string) *batchWriter {
+	buffered := bufio.NewWriter(w)
+	return &batchWriter{buffered: buffered, csv: csv.NewWriter(buffered), format: format}
+}
+
+func (w *batchWriter) write(row *batchRow, rank *lexorank.LexoRank) error {
+	if w.format == "csv" {
+		return w.csv.Write([]string{row.id, rank.String()})
+	}
+	return writeJSONRow(w.buffered, row.rawID, rank.String())
+}
+
+func (w *batchWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	return w.buffered.Flush()
+}
+
+func writeJSONRow(w io.Writer, id json.RawMessage, rank string) error {
+	data, err := json.Marshal(jsonRow{ID: id, Rank: &rank})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
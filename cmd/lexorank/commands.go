@@ -0,0 +1,233 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+func bucketFlag(flags *flag.FlagSet) *string {
+	return flags.String("bucket", "0", "bucket of generated ranks")
+}
+
+func parseBucket(value string) (*lexorank.LexoRankBucket, error) {
+	for _, bucket := range lexorank.LexoRankBuckets {
+		if bucket.String() == value {
+			return bucket, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown bucket: %q", value)
+}
+
+func runBetween(args []string, in io.Reader, out *output) error {
+	between := func(line string) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			out.write(result{Input: line, Error: "expected two ranks"})
+			return
+		}
+		left, err := lexorank.LexoRankParseStrict(fields[0])
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		right, err := lexorank.LexoRankParseStrict(fields[1])
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		rank, err := left.Between(right)
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		out.write(result{Input: line, Rank: rank.String()})
+	}
+	switch len(args) {
+	case 0:
+		return inputs(nil, in, between)
+	case 2:
+		between(args[0] + " " + args[1])
+		return nil
+	}
+	return fmt.Errorf("expected two ranks, got %d", len(args))
+}
+
+func runNext(args []string, in io.Reader, out *output) error {
+	return runStep(args, in, out, (*lexorank.LexoRank).Next)
+}
+
+func runPrev(args []string, in io.Reader, out *output) error {
+	return runStep(args, in, out, (*lexorank.LexoRank).Prev)
+}
+
+func runStep(args []string, in io.Reader, out *output, step func(*lexorank.LexoRank) (*lexorank.LexoRank, error)) error {
+	return inputs(args, in, func(line string) {
+		rank, err := lexorank.LexoRankParseStrict(line)
+		if err == nil {
+			rank, err = step(rank)
+		}
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		out.write(result{Input: line, Rank: rank.String()})
+	})
+}
+
+func runMid(args []string, _ io.Reader, out *output) error {
+	flags := newFlagSet("mid")
+	bucketName := bucketFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	bucket, err := parseBucket(*bucketName)
+	if err != nil {
+		return err
+	}
+	out.write(result{Rank: lexorank.MidLexoRank.InBucket(bucket).String()})
+	return nil
+}
+
+func runParse(args []string, in io.Reader, out *output) error {
+	return inputs(args, in, func(line string) {
+		rank, err := lexorank.LexoRankParseStrict(line)
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		out.write(result{Input: line, Rank: rank.String()})
+	})
+}
+
+func runValidate(args []string, in io.Reader, out *output) error {
+	return inputs(args, in, func(line string) {
+		rank, err := lexorank.LexoRankParseStrict(line)
+		switch {
+		case err != nil:
+			out.write(result{Input: line, Error: err.Error()})
+		case rank.String() != line:
+			out.write(result{Input: line, Error: fmt.Sprintf("not canonical, expected %s", rank)})
+		default:
+			out.write(result{Input: line, Rank: rank.String()})
+		}
+	})
+}
+
+func runSpread(args []string, _ io.Reader, out *output) error {
+	flags := newFlagSet("spread")
+	bucketName := bucketFlag(flags)
+	from := flags.String("from", "", "exclusive lower bound, defaults to the bucket minimum")
+	to := flags.String("to", "", "exclusive upper bound, defaults to the bucket maximum")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected count")
+	}
+	n, err := strconv.Atoi(flags.Arg(0))
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid count: %q", flags.Arg(0))
+	}
+	bucket, err := parseBucket(*bucketName)
+	if err != nil {
+		return err
+	}
+	low, high := lexorank.MinLexoRank.InBucket(bucket), lexorank.MaxLexoRank.InBucket(bucket)
+	if *from != "" {
+		if low, err = lexorank.LexoRankParseStrict(*from); err != nil {
+			return fmt.Errorf("from: %w", err)
+		}
+	}
+	if *to != "" {
+		if high, err = lexorank.LexoRankParseStrict(*to); err != nil {
+			return fmt.Errorf("to: %w", err)
+		}
+	}
+	ranks, err := low.BetweenN(high, n)
+	if err != nil {
+		return err
+	}
+	for _, rank := range ranks {
+		out.write(result{Rank: rank.String()})
+	}
+	return nil
+}
+
+func runConvert(args []string, in io.Reader, out *output) error {
+	flags := newFlagSet("convert")
+	bucketName := flags.String("bucket", "", "target bucket, defaults to the bucket of each rank")
+	from := flags.Int("from", 36, "numeral system of the input: 10, 36, 62 or 64")
+	to := flags.Int("to", 36, "numeral system of the output: 10, 36, 62 or 64")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var bucket *lexorank.LexoRankBucket
+	if *bucketName != "" {
+		var err error
+		if bucket, err = parseBucket(*bucketName); err != nil {
+			return err
+		}
+	}
+	fromSystem, err := parseSystem(*from)
+	if err != nil {
+		return err
+	}
+	toSystem, err := parseSystem(*to)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	var ranks []*lexorank.SystemRank
+	err = inputs(flags.Args(), in, func(line string) {
+		rank, err := lexorank.ParseSystemRank(line, fromSystem)
+		if err != nil {
+			out.write(result{Input: line, Error: err.Error()})
+			return
+		}
+		if bucket != nil {
+			rank = rank.InBucket(bucket)
+		}
+		lines = append(lines, line)
+		ranks = append(ranks, rank)
+	})
+	if err != nil {
+		return err
+	}
+	converted, exact, err := lexorank.ConvertSystemRanks(ranks, toSystem)
+	if err != nil {
+		return err
+	}
+	for idx, rank := range converted {
+		if !exact[idx] {
+			fmt.Fprintf(out.errw, "convert: %s: fraction rounded down\n", lines[idx])
+		}
+		out.write(result{Input: lines[idx], Rank: rank.String()})
+	}
+	return nil
+}
+
+func parseSystem(base int) (lexorank.LexoNumeralSystem, error) {
+	switch base {
+	case 10:
+		return lexorank.NewLexoNumeralSystem10(), nil
+	case 36:
+		return lexorank.NewLexoNumeralSystem36(), nil
+	case 62:
+		return lexorank.NewLexoNumeralSystem62(), nil
+	case 64:
+		return lexorank.NewLexoNumeralSystem64(), nil
+	}
+	return nil, fmt.Errorf("unsupported base: %d", base)
+}
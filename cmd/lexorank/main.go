@@ -0,0 +1,133 @@
+// Command lexorank generates, checks and converts LexoRank values.
+//
+//	lexorank [-json] <command> [flags] [args]
+//
+// Commands that take ranks read them from the arguments, or one per line from
+// stdin when no arguments are given. The rebalance command reads a CSV or JSONL
+// export of (id, rank or position) rows and writes evenly spaced new ranks. It
+// sorts the rows in memory unless -sorted promises a FILE already in order,
+// which is then streamed in two passes.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+type result struct {
+	Input string `json:"input,omitempty"`
+	Rank  string `json:"rank,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type output struct {
+	w      io.Writer
+	errw   io.Writer
+	json   bool
+	failed bool
+}
+
+func (o *output) write(r result) {
+	if r.Error != "" {
+		o.failed = true
+	}
+	if o.json {
+		data, _ := json.Marshal(r)
+		fmt.Fprintf(o.w, "%s\n", data)
+		return
+	}
+	if r.Error != "" {
+		fmt.Fprintf(o.w, "error: %s: %s\n", r.Input, r.Error)
+		return
+	}
+	fmt.Fprintln(o.w, r.Rank)
+}
+
+type command struct {
+	usage string
+	run   func(args []string, in io.Reader, out *output) error
+}
+
+var commands = map[string]command{
+	"between":  {usage: "between LEFT RIGHT", run: runBetween},
+	"next":     {usage: "next [RANK...]", run: runNext},
+	"prev":     {usage: "prev [RANK...]", run: runPrev},
+	"mid":      {usage: "mid [-bucket B]", run: runMid},
+	"parse":    {usage: "parse [RANK...]", run: runParse},
+	"validate": {usage: "validate [RANK...]", run: runValidate},
+	"spread":   {usage: "spread [-bucket B] [-from RANK] [-to RANK] N", run: runSpread},
+	"convert":  {usage: "convert [-bucket B] [-from BASE] [-to BASE] [RANK...]", run: runConvert},
+	"rebalance": {
+		usage: "rebalance [-format csv|jsonl] [-key rank|position] [-bucket B] [-header=false] [-report FILE] [-sorted] [FILE]",
+		run:   runRebalance,
+	},
+	"simulate": {
+		usage: "simulate [-workload W] [-strategy S] [-initial N] [-inserts N] [-max-length N] [-density D] [-seed N]",
+		run:   runSimulate,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lexorank", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print results as JSON lines")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: lexorank [-json] <command> [flags] [args]")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(stderr, "  %s\n", commands[name].usage)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command: %s\n", flags.Arg(0))
+		flags.Usage()
+		return 2
+	}
+	out := &output{w: stdout, errw: stderr, json: *asJSON}
+	if err := cmd.run(flags.Args()[1:], stdin, out); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", flags.Arg(0), err)
+		return 2
+	}
+	if out.failed {
+		return 1
+	}
+	return 0
+}
+
+func inputs(args []string, in io.Reader, each func(line string)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			each(arg)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			each(line)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{
+			name: "between",
+			args: []string{"between", "0|100000:", "0|100001:"},
+			want: "0|100000:i\n",
+		},
+		{
+			name:  "between stdin",
+			args:  []string{"between"},
+			stdin: "0|100000: 0|100002:\n0|100000: 0|100000:\n",
+			want: "0|100001:\n" +
+				"error: 0|100000: 0|100000:: try to rank between issues with same rank this=0|100000: other=0|100000:\n",
+			wantCode: 1,
+		},
+		{
+			name: "next",
+			args: []string{"next", "0|hzzzzz:"},
+			want: "0|i00007:\n",
+		},
+		{
+			name:  "prev stdin",
+			args:  []string{"prev"},
+			stdin: "0|i00007:\n\n0|zzzzzz:\n",
+			want:  "0|hzzzzz:\n0|y00000:\n",
+		},
+		{
+			name: "mid",
+			args: []string{"mid", "-bucket", "1"},
+			want: "1|hzzzzz:\n",
+		},
+		{
+			name: "parse json",
+			args: []string{"-json", "parse", "0|i00000:0", "x"},
+			want: `{"input":"0|i00000:0","rank":"0|i00000:"}` + "\n" +
+				`{"input":"x","error":"invalid rank: \"x\" has no '|'"}` + "\n",
+			wantCode: 1,
+		},
+		{
+			name:     "validate",
+			args:     []string{"validate", "0|i00000:", "0|i00000:0"},
+			want:     "0|i00000:\nerror: 0|i00000:0: not canonical, expected 0|i00000:\n",
+			wantCode: 1,
+		},
+		{
+			name:  "validate strict",
+			args:  []string{"validate"},
+			stdin: "x|000000:\n3|000000:\n0|zzzzzz:1\n0|1000000:\n0|i\n0|zzzzzz:\n",
+			want: "error: x|000000:: invalid rank: unknown bucket \"x\"\n" +
+				"error: 3|000000:: invalid rank: unknown bucket \"3\"\n" +
+				"error: 0|zzzzzz:1: invalid rank: 0|zzzzzz:1 is above 0|zzzzzz:\n" +
+				"error: 0|1000000:: invalid rank: \"0|1000000:\" needs 6 integer digits and a ':'\n" +
+				"error: 0|i: invalid rank: \"0|i\" needs 6 integer digits and a ':'\n" +
+				"0|zzzzzz:\n",
+			wantCode: 1,
+		},
+		{
+			name:     "next above max",
+			args:     []string{"next", "0|zzzzzz:1"},
+			want:     "error: 0|zzzzzz:1: invalid rank: 0|zzzzzz:1 is above 0|zzzzzz:\n",
+			wantCode: 1,
+		},
+		{
+			name: "spread",
+			args: []string{"spread", "-from", "0|100000:", "-to", "0|100004:", "3"},
+			want: "0|100001:\n0|100002:\n0|100003:\n",
+		},
+		{
+			name: "convert",
+			args: []string{"convert", "-bucket", "2", "0|hzzzzz:"},
+			want: "2|hzzzzz:\n",
+		},
+		{
+			name: "convert base",
+			args: []string{"convert", "-to", "10", "0|hzzzzz:", "1|000001:i"},
+			want: "0|1088391167.\n1|0000000001.5\n",
+		},
+		{
+			name: "convert back",
+			args: []string{"convert", "-from", "10", "0|1088391167.", "0|0000000001.5"},
+			want: "0|hzzzzz:\n0|000001:i\n",
+		},
+		{
+			name:     "convert unknown base",
+			args:     []string{"convert", "-to", "16", "0|hzzzzz:"},
+			wantCode: 2,
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"shuffle"},
+			wantCode: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equalf(t, tt.wantCode, code, "run(%v) stderr: %s", tt.args, stderr.String())
+			assert.Equalf(t, tt.want, stdout.String(), "run(%v)", tt.args)
+		})
+	}
+}
+
+func TestRunRebalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		want       string
+		wantReport string
+		wantCode   int
+	}{
+		{
+			name:  "csv positions",
+			args:  []string{"rebalance", "-key", "position"},
+			stdin: "id,position\nc,30\na,10\nb,20\n",
+			want:  "a,0|8zzzzz:\nb,0|hzzzzz:\nc,0|qzzzzz:\n",
+		},
+		{
+			name:  "csv ranks with problems",
+			args:  []string{"rebalance", "-header=false", "-bucket", "1"},
+			stdin: "a,0|100000:\nb,bad\nc,0|100000:\n",
+			want:  "a,1|8zzzzz:\nc,1|hzzzzz:\nb,1|qzzzzz:\n",
+			wantReport: "line 3: id c: duplicate rank \"0|100000:\"\n" +
+				"line 2: id b: invalid rank \"bad\"\n",
+			wantCode: 1,
+		},
+		{
+			name:  "jsonl",
+			args:  []string{"rebalance", "-format", "jsonl", "-key", "position"},
+			stdin: `{"id": 2, "position": 5}` + "\n" + `{"id": "x", "position": 1}` + "\n",
+			want:  `{"id":"x","rank":"0|bzzzzz:"}` + "\n" + `{"id":2,"rank":"0|nzzzzz:"}` + "\n",
+		},
+		{
+			name:       "csv rank too wide",
+			args:       []string{"rebalance"},
+			stdin:      "id,rank\na,0|1000000:\nb,0|100000:\n",
+			want:       "b,0|bzzzzz:\na,0|nzzzzz:\n",
+			wantReport: "line 2: id a: invalid rank \"0|1000000:\"\n",
+			wantCode:   1,
+		},
+		{
+			name: "jsonl both fields by position",
+			args: []string{"rebalance", "-format", "jsonl", "-key", "position"},
+			stdin: `{"id": "a", "position": 2, "rank": "0|100000:"}` + "\n" +
+				`{"id": "b", "position": 1, "rank": "0|200000:"}` + "\n",
+			want: `{"id":"b","rank":"0|bzzzzz:"}` + "\n" + `{"id":"a","rank":"0|nzzzzz:"}` + "\n",
+		},
+		{
+			name: "jsonl both fields by rank",
+			args: []string{"rebalance", "-format", "jsonl"},
+			stdin: `{"id": "a", "position": 2, "rank": "0|100000:"}` + "\n" +
+				`{"id": "b", "position": 1, "rank": "0|200000:"}` + "\n",
+			want: `{"id":"a","rank":"0|bzzzzz:"}` + "\n" + `{"id":"b","rank":"0|nzzzzz:"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			assert.Equalf(t, tt.wantCode, code, "run(%v)", tt.args)
+			assert.Equalf(t, tt.want, stdout.String(), "run(%v)", tt.args)
+			assert.Equalf(t, tt.wantReport, stderr.String(), "run(%v)", tt.args)
+		})
+	}
+}
+
+func TestRunRebalance_Sorted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ranks.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id,rank\na,0|100000:\nb,bad\nc,0|100000:\nd,0|200000:\n"), 0o600))
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"rebalance", "-sorted", path}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "a,0|777777:\nc,0|eeeeee:\nd,0|llllll:\nb,0|ssssss:\n", stdout.String())
+	assert.Equal(t, "line 3: id b: invalid rank \"bad\"\n"+
+		"line 4: id c: duplicate rank \"0|100000:\"\n", stderr.String())
+
+	unsorted := filepath.Join(t.TempDir(), "unsorted.csv")
+	require.NoError(t, os.WriteFile(unsorted, []byte("a,0|200000:\nb,0|100000:\n"), 0o600))
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"rebalance", "-sorted", "-header=false", unsorted}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "line 2: id b: rank \"0|100000:\" below the previous row")
+
+	stderr.Reset()
+	code = run([]string{"rebalance", "-sorted"}, strings.NewReader("a,0|100000:\n"), &stdout, &stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "-sorted needs a FILE")
+}
+
+func TestRunSimulate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-json", "simulate", "-workload", "hotspot", "-strategy", "between", "-initial", "2", "-inserts", "40", "-max-length", "10"},
+		strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), `"workload":"hotspot","strategy":"between"`)
+	assert.NotContains(t, stdout.String(), `"rebalances":0`)
+}
+
+func TestRunSimulate_UnknownStrategy(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"simulate", "-strategy", "foo", "-inserts", "1"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), `unknown strategy: "foo"`)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/LEXASOFT/LexoRank/simulate"
+)
+
+type simulateResult struct {
+	Workload        simulate.Workload `json:"workload"`
+	Strategy        simulate.Strategy `json:"strategy"`
+	Lengths         []int             `json:"lengths"`
+	ScaleP50        int               `json:"scale_p50"`
+	ScaleP99        int               `json:"scale_p99"`
+	ScaleMax        int               `json:"scale_max"`
+	Rebalances      int               `json:"rebalances"`
+	RebalancedRanks int               `json:"rebalanced_ranks"`
+}
+
+func runSimulate(args []string, _ io.Reader, out *output) error {
+	flags := newFlagSet("simulate")
+	workload := flags.String("workload", "all", "random, append, prepend, hotspot, drag-to-top or all")
+	strategy := flags.String("strategy", "all", "between, step or all")
+	cfg := simulate.Config{}
+	flags.IntVar(&cfg.Initial, "initial", 100, "items before the workload starts")
+	flags.IntVar(&cfg.Inserts, "inserts", 10000, "inserts per run")
+	flags.IntVar(&cfg.MaxLength, "max-length", 0, "rank length that triggers a rebalance, 0 disables")
+	flags.Float64Var(&cfg.Density, "density", 0.5, "rebalance target density")
+	flags.Int64Var(&cfg.Seed, "seed", 1, "random seed")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	workloads := simulate.Workloads
+	if *workload != "all" {
+		workloads = []simulate.Workload{simulate.Workload(*workload)}
+	}
+	strategies := simulate.Strategies
+	if *strategy != "all" {
+		strategies = []simulate.Strategy{simulate.Strategy(*strategy)}
+	}
+
+	table := tabwriter.NewWriter(out.w, 0, 4, 2, ' ', 0)
+	if !out.json {
+		fmt.Fprintln(table, "WORKLOAD\tSTRATEGY\tMAX LENGTH\tSCALE P50\tP99\tMAX\tREBALANCES\tREWRITTEN")
+	}
+	for _, w := range workloads {
+		for _, s := range strategies {
+			cfg.Workload, cfg.Strategy = w, s
+			result, err := simulate.Run(cfg)
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", w, s, err)
+			}
+			row := simulateResult{
+				Workload:        w,
+				Strategy:        s,
+				Lengths:         result.Lengths,
+				ScaleP50:        result.ScaleP50,
+				ScaleP99:        result.ScaleP99,
+				ScaleMax:        result.ScaleMax,
+				Rebalances:      result.Rebalances,
+				RebalancedRanks: result.RebalancedRanks,
+			}
+			if out.json {
+				data, _ := json.Marshal(row)
+				fmt.Fprintf(out.w, "%s\n", data)
+				continue
+			}
+			finalLength := 0
+			if len(row.Lengths) > 0 {
+				finalLength = row.Lengths[len(row.Lengths)-1]
+			}
+			fmt.Fprintf(table, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\n", w, s, finalLength,
+				row.ScaleP50, row.ScaleP99, row.ScaleMax, row.Rebalances, row.RebalancedRanks)
+		}
+	}
+	return table.Flush()
+}
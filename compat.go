@@ -0,0 +1,13 @@
+package lexorank
+
+// Compatibility selects how Prev steps down from a fractional rank. The legacy
+// Go Prev steps down from the ceiling and, near the minimum, calls Between with
+// its arguments swapped. CompatibilityJira steps down from the floor and calls
+// Between(min, rank), as Jira's genPrev is described to. Neither mode has been
+// checked against Atlassian's Java library; see testdata/README.md.
+type Compatibility int
+
+const (
+	CompatibilityLegacy Compatibility = iota
+	CompatibilityJira
+)
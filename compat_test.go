@@ -0,0 +1,87 @@
+package lexorank
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jiraVector struct {
+	Op   string `json:"op"`
+	A    string `json:"a"`
+	B    string `json:"b,omitempty"`
+	Want string `json:"want"`
+}
+
+func TestJiraVectors(t *testing.T) {
+	data, err := os.ReadFile("testdata/jira_vectors.json")
+	require.NoError(t, err)
+	var vectors []jiraVector
+	require.NoError(t, json.Unmarshal(data, &vectors))
+
+	for _, v := range vectors {
+		t.Run(v.Op+" "+v.A+" "+v.B, func(t *testing.T) {
+			a, err := LexoRankParse(v.A)
+			require.NoError(t, err)
+			var got *LexoRank
+			switch v.Op {
+			case "between":
+				b, err := LexoRankParse(v.B)
+				require.NoError(t, err)
+				got, err = a.Between(b)
+				require.NoError(t, err)
+			case "next":
+				got, err = a.Next()
+				require.NoError(t, err)
+			case "prev":
+				got, err = a.PrevWith(CompatibilityJira)
+				require.NoError(t, err)
+			case "format":
+				got = a
+			default:
+				t.Fatalf("unknown op %q", v.Op)
+			}
+			assert.Equal(t, v.Want, got.String())
+		})
+	}
+}
+
+func TestLexoRank_PrevWith(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		legacy string
+		jira   string
+	}{
+		{
+			name:   "integer",
+			value:  "0|hzzzzz:",
+			legacy: "0|hzzzzr:",
+			jira:   "0|hzzzzr:",
+		},
+		{
+			name:   "fraction",
+			value:  "0|i00001:i",
+			legacy: "0|hzzzzu:",
+			jira:   "0|hzzzzt:",
+		},
+		{
+			name:   "near min",
+			value:  "0|00000a:zz",
+			legacy: "0|000003:",
+			jira:   "0|000002:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, _ := LexoRankParse(tt.value)
+			legacy, _ := rank.PrevWith(CompatibilityLegacy)
+			jira, _ := rank.PrevWith(CompatibilityJira)
+			assert.Equal(t, tt.legacy, legacy.String())
+			assert.Equal(t, tt.jira, jira.String())
+		})
+	}
+}
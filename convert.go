@@ -0,0 +1,252 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Convert re-encodes the integer in another numeral system. Integers convert
+// exactly.
+func (d *LexoInteger) Convert(sys LexoNumeralSystem) *LexoInteger {
+	if d.sys.GetBase() == sys.GetBase() {
+		return NewLexoInteger(sys, d.sign, d.mag)
+	}
+	return lexoIntegerFromBig(sys, d.bigInt())
+}
+
+func (d *LexoInteger) bigInt() *big.Int {
+	base := big.NewInt(int64(d.sys.GetBase()))
+	value := new(big.Int)
+	for idx := len(d.mag) - 1; idx >= 0; idx-- {
+		value.Mul(value, base)
+		value.Add(value, big.NewInt(int64(d.mag[idx])))
+	}
+	if d.sign < 0 {
+		value.Neg(value)
+	}
+	return value
+}
+
+func lexoIntegerFromBig(sys LexoNumeralSystem, value *big.Int) *LexoInteger {
+	sign := value.Sign()
+	if sign == 0 {
+		return lexoIntegerZero(sys)
+	}
+	rest := new(big.Int).Abs(value)
+	base := big.NewInt(int64(sys.GetBase()))
+	digit := new(big.Int)
+	var mag []byte
+	for rest.Sign() > 0 {
+		rest.QuoRem(rest, base, digit)
+		mag = append(mag, byte(digit.Int64()))
+	}
+	return makeLexoInteger(sys, sign, mag)
+}
+
+// ConvertScale returns the smallest scale in the to system whose step is no
+// coarser than the step of scale in the from system. Decimals of at most that
+// scale keep their relative order when converted at the returned scale.
+func ConvertScale(from, to LexoNumeralSystem, scale int) int {
+	if scale <= 0 {
+		return 0
+	}
+	limit := new(big.Int).Exp(big.NewInt(int64(from.GetBase())), big.NewInt(int64(scale)), nil)
+	base := big.NewInt(int64(to.GetBase()))
+	step := big.NewInt(1)
+	result := 0
+	for ; step.Cmp(limit) < 0; result++ {
+		step.Mul(step, base)
+	}
+	return result
+}
+
+// Convert re-encodes the decimal in another numeral system at the scale given by
+// ConvertScale. The result is rounded down when the fraction has no finite
+// representation at that scale, in which case exact is false.
+func (d *LexoDecimal) Convert(sys LexoNumeralSystem) (*LexoDecimal, bool) {
+	return d.ConvertAt(sys, ConvertScale(d.GetSystem(), sys, d.scale))
+}
+
+// ConvertAt re-encodes the decimal in another numeral system, rounding down to
+// at most scale fraction digits.
+func (d *LexoDecimal) ConvertAt(sys LexoNumeralSystem, scale int) (*LexoDecimal, bool) {
+	numerator := d.mag.bigInt()
+	numerator.Mul(numerator, new(big.Int).Exp(big.NewInt(int64(sys.GetBase())), big.NewInt(int64(scale)), nil))
+	denominator := new(big.Int).Exp(big.NewInt(int64(d.GetSystem().GetBase())), big.NewInt(int64(d.scale)), nil)
+	quotient, remainder := new(big.Int).DivMod(numerator, denominator, new(big.Int))
+	return LexoDecimalMake(lexoIntegerFromBig(sys, quotient), scale), remainder.Sign() == 0
+}
+
+// SystemRank is a rank written in any numeral system, as returned by
+// ConvertLexoRanks and ParseSystemRank. It only prints and compares; ranks are
+// allocated in LexoRankSystem, so convert a SystemRank back and call LexoRank
+// before ranking next to it.
+type SystemRank struct {
+	value   string
+	bucket  *LexoRankBucket
+	decimal *LexoDecimal
+}
+
+func newSystemRank(bucket *LexoRankBucket, decimal *LexoDecimal) *SystemRank {
+	return &SystemRank{
+		value:   bucket.String() + "|" + formatDecimal(decimal),
+		bucket:  bucket,
+		decimal: decimal,
+	}
+}
+
+// ParseSystemRank parses a rank written in the given numeral system.
+func ParseSystemRank(str string, sys LexoNumeralSystem) (*SystemRank, error) {
+	bucket, decimal, err := parseRankParts(str, sys)
+	if err != nil {
+		return nil, err
+	}
+	return newSystemRank(bucket, decimal), nil
+}
+
+func parseRankParts(str string, sys LexoNumeralSystem) (*LexoRankBucket, *LexoDecimal, error) {
+	split := strings.Split(str, "|")
+	if len(split) != 2 {
+		return nil, nil, errors.New("parts not two")
+	}
+	bucket, err := NewLexoRankBucket(split[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("lexo rank bucket: %w", err)
+	}
+	decimal, err := LexoDecimalParse(split[1], sys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lexo decimal parse: %w", err)
+	}
+	formatted := decimal.String()
+	if width := strings.IndexByte(formatted, sys.GetRadixPointChar()); width > integerWidth(sys) || width < 0 && len(formatted) > integerWidth(sys) {
+		return nil, nil, fmt.Errorf("integer part of %q longer than %d digits", split[1], integerWidth(sys))
+	}
+	return bucket, decimal, nil
+}
+
+func (r *SystemRank) String() string {
+	if r == nil {
+		return ""
+	}
+	return r.value
+}
+
+func (r *SystemRank) GetBucket() *LexoRankBucket {
+	if r == nil {
+		return nil
+	}
+	return r.bucket
+}
+
+func (r *SystemRank) GetSystem() LexoNumeralSystem {
+	if r == nil {
+		return nil
+	}
+	return r.decimal.GetSystem()
+}
+
+// Compare orders ranks by bucket and then by value. Values compare exactly even
+// when the two ranks are in different numeral systems.
+func (r *SystemRank) Compare(other *SystemRank) int {
+	switch {
+	case r == nil && other == nil:
+		return 0
+	case r == nil:
+		return -1
+	case other == nil:
+		return 1
+	}
+	if cmp := r.bucket.value.Compare(other.bucket.value); cmp != 0 {
+		return cmp
+	}
+	if r.decimal.GetSystem().GetBase() == other.decimal.GetSystem().GetBase() {
+		return r.decimal.Compare(other.decimal)
+	}
+	left := r.decimal.mag.bigInt()
+	left.Mul(left, decimalDenominator(other.decimal))
+	right := other.decimal.mag.bigInt()
+	right.Mul(right, decimalDenominator(r.decimal))
+	return left.Cmp(right)
+}
+
+func decimalDenominator(d *LexoDecimal) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(d.GetSystem().GetBase())), big.NewInt(int64(d.scale)), nil)
+}
+
+// InBucket returns the same value in another bucket.
+func (r *SystemRank) InBucket(bucket *LexoRankBucket) *SystemRank {
+	if r == nil {
+		return nil
+	}
+	return newSystemRank(bucket, r.decimal)
+}
+
+// LexoRank returns the rank as a *LexoRank. Only ranks in LexoRankSystem have
+// one; convert others with ConvertSystemRanks first.
+func (r *SystemRank) LexoRank() (*LexoRank, error) {
+	if r == nil {
+		return nil, NilRankErr
+	}
+	if sys := r.decimal.GetSystem(); sys.GetBase() != LexoRankSystem.GetBase() {
+		return nil, fmt.Errorf("rank %s is in base %d, not %d", r, sys.GetBase(), LexoRankSystem.GetBase())
+	}
+	return NewLexoRank(r.bucket, r.decimal), nil
+}
+
+// Convert re-encodes the rank in another numeral system. See ConvertLexoRanks
+// for converting more than one rank.
+func (i *LexoRank) Convert(sys LexoNumeralSystem) (*SystemRank, bool) {
+	if i == nil {
+		return nil, true
+	}
+	decimal, exact := i.decimal.Convert(sys)
+	return newSystemRank(i.bucket, decimal), exact
+}
+
+// ConvertLexoRanks re-encodes ranks in another numeral system. All ranks share
+// one target scale, so the converted ranks keep the relative order of the input,
+// including ties. exact reports, per rank, whether the fraction survived
+// unchanged; inexact ranks are rounded down.
+func ConvertLexoRanks(ranks []*LexoRank, sys LexoNumeralSystem) ([]*SystemRank, []bool, error) {
+	wrapped := make([]*SystemRank, len(ranks))
+	for idx, rank := range ranks {
+		if rank == nil {
+			return nil, nil, fmt.Errorf("rank %d: %w", idx, NilRankErr)
+		}
+		wrapped[idx] = &SystemRank{value: rank.value, bucket: rank.bucket, decimal: rank.decimal}
+	}
+	return ConvertSystemRanks(wrapped, sys)
+}
+
+// ConvertSystemRanks is ConvertLexoRanks for ranks that may already be in
+// another numeral system, such as ranks read with ParseSystemRank.
+func ConvertSystemRanks(ranks []*SystemRank, sys LexoNumeralSystem) ([]*SystemRank, []bool, error) {
+	scale := 0
+	for idx, rank := range ranks {
+		if rank == nil {
+			return nil, nil, fmt.Errorf("rank %d: %w", idx, NilRankErr)
+		}
+		if rankScale := ConvertScale(rank.decimal.GetSystem(), sys, rank.decimal.scale); rankScale > scale {
+			scale = rankScale
+		}
+	}
+	converted := make([]*SystemRank, len(ranks))
+	exact := make([]bool, len(ranks))
+	for idx, rank := range ranks {
+		var decimal *LexoDecimal
+		decimal, exact[idx] = rank.decimal.ConvertAt(sys, scale)
+		converted[idx] = newSystemRank(rank.bucket, decimal)
+	}
+	return converted, exact, nil
+}
+
+// integerWidth is the number of digits the rank integer part takes in sys: the
+// width needed to hold every integer below MaxLexoRank.
+func integerWidth(sys LexoNumeralSystem) int {
+	if sys.GetBase() == LexoRankSystem.GetBase() {
+		return 6
+	}
+	return ConvertScale(LexoRankSystem, sys, 6)
+}
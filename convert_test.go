@@ -0,0 +1,138 @@
+package lexorank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexoInteger_Convert(t *testing.T) {
+	tests := []struct {
+		value string
+		sys   LexoNumeralSystem
+		want  string
+	}{
+		{value: "0", sys: NewLexoNumeralSystem10(), want: "0"},
+		{value: "zz", sys: NewLexoNumeralSystem10(), want: "1295"},
+		{value: "-zz", sys: NewLexoNumeralSystem10(), want: "-1295"},
+		{value: "100", sys: NewLexoNumeralSystem64(), want: "KG"},
+		{value: "hzzzzz", sys: NewLexoNumeralSystem62(), want: "1BemGF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			value, err := LexoIntegerParse(tt.value, LexoRankSystem)
+			assert.NoError(t, err)
+			got := value.Convert(tt.sys)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.value, got.Convert(LexoRankSystem).String())
+		})
+	}
+}
+
+func TestConvertScale(t *testing.T) {
+	assert.Equal(t, 0, ConvertScale(LexoRankSystem, NewLexoNumeralSystem64(), 0))
+	assert.Equal(t, 1, ConvertScale(LexoRankSystem, NewLexoNumeralSystem64(), 1))
+	assert.Equal(t, 2, ConvertScale(LexoRankSystem, NewLexoNumeralSystem10(), 1))
+	assert.Equal(t, 6, ConvertScale(LexoRankSystem, NewLexoNumeralSystem64(), 6))
+	assert.Equal(t, 2, ConvertScale(NewLexoNumeralSystem64(), LexoRankSystem, 1))
+}
+
+func TestLexoRank_Convert(t *testing.T) {
+	tests := []struct {
+		rank      string
+		sys       LexoNumeralSystem
+		want      string
+		wantExact bool
+	}{
+		{rank: "0|hzzzzz:", sys: NewLexoNumeralSystem10(), want: "0|1088391167.", wantExact: true},
+		{rank: "0|000001:i", sys: NewLexoNumeralSystem10(), want: "0|0000000001.5", wantExact: true},
+		{rank: "0|000001:1", sys: NewLexoNumeralSystem10(), want: "0|0000000001.02", wantExact: false},
+		{rank: "1|hzzzzz:i", sys: NewLexoNumeralSystem64(), want: "1|10rsVz:W", wantExact: true},
+		{rank: "2|000000:1", sys: NewLexoNumeralSystem64(), want: "2|000000:1", wantExact: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rank, func(t *testing.T) {
+			rank, err := LexoRankParse(tt.rank)
+			assert.NoError(t, err)
+			got, exact := rank.Convert(tt.sys)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantExact, exact)
+			parsed, err := ParseSystemRank(got.String(), tt.sys)
+			assert.NoError(t, err)
+			assert.Equal(t, got.String(), parsed.String())
+			assert.Equal(t, 0, parsed.Compare(got))
+
+			_, err = got.LexoRank()
+			assert.Error(t, err)
+			source, _, err := ConvertLexoRanks([]*LexoRank{rank}, LexoRankSystem)
+			assert.NoError(t, err)
+			if exact {
+				assert.Equal(t, 0, got.Compare(source[0]))
+			} else {
+				assert.Equal(t, -1, got.Compare(source[0]))
+			}
+			back, err := source[0].LexoRank()
+			assert.NoError(t, err)
+			assert.Equal(t, rank, back)
+		})
+	}
+}
+
+func TestConvertLexoRanks(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ranks := []*LexoRank{MinLexoRank, MaxLexoRank}
+	for idx := 0; idx < 300; idx++ {
+		left, right := ranks[rnd.Intn(len(ranks))], ranks[rnd.Intn(len(ranks))]
+		if left.Compare(right) == 0 {
+			continue
+		}
+		rank, err := left.Between(right)
+		assert.NoError(t, err)
+		ranks = append(ranks, rank)
+	}
+	ranks = append(ranks, ranks[5])
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i].Compare(ranks[j]) < 0 })
+
+	for _, sys := range []LexoNumeralSystem{NewLexoNumeralSystem10(), NewLexoNumeralSystem62(), NewLexoNumeralSystem64()} {
+		converted, exact, err := ConvertLexoRanks(ranks, sys)
+		assert.NoError(t, err)
+		assert.Len(t, exact, len(ranks))
+		for idx := 1; idx < len(converted); idx++ {
+			want := ranks[idx-1].Compare(ranks[idx])
+			assert.Equalf(t, want, converted[idx-1].Compare(converted[idx]), "%s %s", converted[idx-1], converted[idx])
+		}
+		back, _, err := ConvertSystemRanks(converted, LexoRankSystem)
+		assert.NoError(t, err)
+		for idx, rank := range back {
+			if exact[idx] {
+				assert.Equal(t, ranks[idx].String(), rank.String())
+				assert.Equal(t, 0, rank.Compare(converted[idx]))
+			}
+		}
+	}
+
+	_, _, err := ConvertLexoRanks([]*LexoRank{MinLexoRank, nil}, NewLexoNumeralSystem10())
+	assert.ErrorIs(t, err, NilRankErr)
+	_, _, err = ConvertSystemRanks([]*SystemRank{nil}, LexoRankSystem)
+	assert.ErrorIs(t, err, NilRankErr)
+}
+
+func TestSystemRank_Compare(t *testing.T) {
+	decimal := NewLexoNumeralSystem10()
+	low, err := ParseSystemRank("0|0000000001.5", decimal)
+	assert.NoError(t, err)
+	high, err := ParseSystemRank("0|000001:j", LexoRankSystem)
+	assert.NoError(t, err)
+	other, err := ParseSystemRank("1|0000000000.", decimal)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, low.Compare(high))
+	assert.Equal(t, 1, high.Compare(low))
+	assert.Equal(t, -1, high.Compare(other))
+	assert.Equal(t, 1, low.Compare(nil))
+	assert.Equal(t, "1|0000000001.5", low.InBucket(LexoRankBucket1).String())
+
+	_, err = ParseSystemRank("0|10000000000.", decimal)
+	assert.Error(t, err)
+}
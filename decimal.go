@@ -3,6 +3,7 @@ package lexorank
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -58,6 +59,9 @@ func (d *LexoDecimal) String() string {
 	if head == d.mag.GetSystem().GetPositiveChar() || head == d.mag.GetSystem().GetNegativeChar() {
 		shift = 1
 	}
+	if digits := len(intStr) - shift; digits <= d.scale {
+		intStr = intStr[:shift] + strings.Repeat(string(d.GetSystem().Char(0)), d.scale+1-digits) + intStr[shift:]
+	}
 	var sb strings.Builder
 	radixPosition := len(intStr) - d.scale + shift
 	for idx := 0; idx < radixPosition; idx++ {
@@ -269,3 +273,109 @@ func (d *LexoDecimal) half() *LexoDecimal {
 	mag := NewLexoInteger(system, 1, []byte{mid})
 	return LexoDecimalMake(mag, 1)
 }
+
+func (d *LexoDecimal) floorAt(scale int) *LexoInteger {
+	return d.mag.ShiftLeft(scale - d.scale)
+}
+
+func (d *LexoDecimal) ceilAt(scale int) *LexoInteger {
+	floor := d.floorAt(scale)
+	if scale >= d.scale || LexoDecimalMake(floor, scale).Equals(d) {
+		return floor
+	}
+	ceil, _ := floor.Add(lexoIntegerOne(floor.GetSystem()))
+	return ceil
+}
+
+func (d *LexoDecimal) slots(other *LexoDecimal, scale int) *LexoInteger {
+	width, _ := other.ceilAt(scale).Sub(d.floorAt(scale))
+	slots, _ := width.Sub(lexoIntegerOne(width.GetSystem()))
+	return slots
+}
+
+// Capacity counts the decimals strictly between d and other that have at most
+// scale fractional digits, saturating at math.MaxInt64.
+func (d *LexoDecimal) Capacity(other *LexoDecimal, scale int) int64 {
+	low, high := d, other
+	switch cmp := d.Compare(other); {
+	case cmp == 0 || scale < 0:
+		return 0
+	case cmp > 0:
+		low, high = other, d
+	}
+	capacity, _ := low.slots(high, scale).int64()
+	return capacity
+}
+
+func (d *LexoDecimal) spread(other *LexoDecimal, n int, density float64) []*LexoDecimal {
+	spread := newDecimalSpread(d, other, n, density)
+	result := make([]*LexoDecimal, n)
+	for k := range result {
+		result[k] = spread.at(k)
+	}
+	return result
+}
+
+// decimalSpread places n decimals between low and high. Without weights the
+// gaps around them are equal; with weights, gap k gets a share of the width
+// proportional to its weight, and cumulative holds the running weight totals.
+type decimalSpread struct {
+	low        *LexoInteger
+	width      *LexoInteger
+	scale      int
+	n          int
+	cumulative []int64
+}
+
+func newDecimalSpread(low, high *LexoDecimal, n int, density float64) *decimalSpread {
+	need := lexoIntegerFromInt(low.GetSystem(), int64(math.Ceil(float64(n)/density)))
+	scale := 0
+	for low.slots(high, scale).Compare(need) < 0 {
+		scale++
+	}
+	floor := low.floorAt(scale)
+	width, _ := high.ceilAt(scale).Sub(floor)
+	return &decimalSpread{low: floor, width: width, scale: scale, n: n}
+}
+
+// newWeightedDecimalSpread spreads len(weights)-1 decimals so that the gap
+// before the k-th, and the gap after the last, follow weights. The scale is
+// deep enough that the lightest gap still spans at least one step. Weights
+// must be positive and total at most maxSpreadWeight.
+func newWeightedDecimalSpread(low, high *LexoDecimal, weights []int64, density float64) *decimalSpread {
+	spread := newDecimalSpread(low, high, len(weights)-1, density)
+	spread.cumulative = make([]int64, len(weights))
+	lightest := weights[0]
+	var total int64
+	for idx, weight := range weights {
+		total += weight
+		spread.cumulative[idx] = total
+		if weight < lightest {
+			lightest = weight
+		}
+	}
+	need := lexoIntegerFromInt(low.GetSystem(), total)
+	for {
+		span, _ := spread.width.Multiply(lexoIntegerFromInt(low.GetSystem(), lightest))
+		if span.Compare(need) >= 0 {
+			return spread
+		}
+		spread.scale++
+		spread.low = low.floorAt(spread.scale)
+		spread.width, _ = high.ceilAt(spread.scale).Sub(spread.low)
+	}
+}
+
+// maxSpreadWeight keeps the long division in at within int64 for bases up to
+// 64.
+const maxSpreadWeight = math.MaxInt64 / 64
+
+func (s *decimalSpread) at(k int) *LexoDecimal {
+	part, total := int64(k+1), int64(s.n+1)
+	if s.cumulative != nil {
+		part, total = s.cumulative[k], s.cumulative[s.n]
+	}
+	offset, _ := s.width.Multiply(lexoIntegerFromInt(s.low.GetSystem(), part))
+	pos, _ := s.low.Add(offset.divSmall(total))
+	return LexoDecimalMake(pos, s.scale)
+}
@@ -128,6 +128,18 @@ func TestLexoDecimal_String(t *testing.T) {
 			},
 			want: "3:14159",
 		},
+		{
+			name: "leading fraction zeros",
+			decimal: &LexoDecimal{
+				mag: &LexoInteger{
+					sys:  NewLexoNumeralSystem36(),
+					sign: 1,
+					mag:  []byte{18},
+				},
+				scale: 3,
+			},
+			want: "0:00i",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
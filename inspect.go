@@ -0,0 +1,109 @@
+package lexorank
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var _ fmt.Formatter = (*LexoRank)(nil)
+
+func (i *LexoRank) GetBucket() *LexoRankBucket {
+	if i == nil {
+		return nil
+	}
+	return i.bucket
+}
+
+func (i *LexoRank) GetDecimal() *LexoDecimal {
+	if i == nil {
+		return nil
+	}
+	return i.decimal
+}
+
+// GetScale returns the number of fraction digits.
+func (i *LexoRank) GetScale() int {
+	if i == nil {
+		return 0
+	}
+	return i.decimal.scale
+}
+
+// GetLength returns the length of the rank string.
+func (i *LexoRank) GetLength() int {
+	return len(i.String())
+}
+
+// LexoRankInfo is the breakdown of a rank returned by Inspect.
+type LexoRankInfo struct {
+	Rank     string  `json:"rank"`
+	Bucket   string  `json:"bucket"`
+	Integer  string  `json:"integer"`
+	Fraction string  `json:"fraction"`
+	Scale    int     `json:"scale"`
+	Length   int     `json:"length"`
+	Position float64 `json:"position"`
+}
+
+func (info LexoRankInfo) String() string {
+	return fmt.Sprintf("%s{bucket:%s integer:%s fraction:%s scale:%d length:%d position:%.6f}",
+		info.Rank, info.Bucket, info.Integer, info.Fraction, info.Scale, info.Length, info.Position)
+}
+
+// Inspect breaks the rank into its parts. Position is the approximate Fraction
+// of the way through the bucket.
+func (i *LexoRank) Inspect() LexoRankInfo {
+	if i == nil {
+		return LexoRankInfo{}
+	}
+	_, digits, _ := strings.Cut(i.value, "|")
+	integer, fraction, _ := strings.Cut(digits, string(i.decimal.GetSystem().GetRadixPointChar()))
+	position, _ := i.Fraction().Float64()
+	return LexoRankInfo{
+		Rank:     i.value,
+		Bucket:   i.bucket.String(),
+		Integer:  integer,
+		Fraction: fraction,
+		Scale:    i.decimal.scale,
+		Length:   len(i.value),
+		Position: position,
+	}
+}
+
+// Format prints the rank string for %s, %v and %q, and the Inspect breakdown
+// for %+v.
+// Width, precision and flags apply as they do to a string.
+func (i *LexoRank) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, formatDirective(f, 's'), i.Inspect().String())
+	case verb == 's' || verb == 'v':
+		fmt.Fprintf(f, formatDirective(f, 's'), i.String())
+	case verb == 'q':
+		fmt.Fprintf(f, formatDirective(f, 'q'), i.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(*lexorank.LexoRank=%s)", verb, i.String())
+	}
+}
+
+// formatDirective rebuilds the directive of f, with its flags, width and
+// precision, for verb.
+func formatDirective(f fmt.State, verb rune) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			sb.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		sb.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := f.Precision(); ok {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(precision))
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
@@ -0,0 +1,50 @@
+package lexorank
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexoRank_Getters(t *testing.T) {
+	rank, err := LexoRankParse("1|hzzzzz:i")
+	assert.NoError(t, err)
+	assert.Equal(t, LexoRankBucket1, rank.GetBucket())
+	assert.Equal(t, "hzzzzz:i", rank.GetDecimal().String())
+	assert.Equal(t, 1, rank.GetScale())
+	assert.Equal(t, 10, rank.GetLength())
+
+	var nilRank *LexoRank
+	assert.Nil(t, nilRank.GetBucket())
+	assert.Nil(t, nilRank.GetDecimal())
+	assert.Equal(t, 0, nilRank.GetScale())
+	assert.Equal(t, 0, nilRank.GetLength())
+}
+
+func TestLexoRank_Inspect(t *testing.T) {
+	rank, err := LexoRankParse("1|hzzzzz:i")
+	assert.NoError(t, err)
+	info := rank.Inspect()
+	assert.Equal(t, "1|hzzzzz:i", info.Rank)
+	assert.Equal(t, "1", info.Bucket)
+	assert.Equal(t, "hzzzzz", info.Integer)
+	assert.Equal(t, "i", info.Fraction)
+	assert.Equal(t, 1, info.Scale)
+	assert.Equal(t, 10, info.Length)
+	assert.InDelta(t, 0.5, info.Position, 1e-6)
+}
+
+func TestLexoRank_Format(t *testing.T) {
+	rank, err := LexoRankParse("0|100000:")
+	assert.NoError(t, err)
+	assert.Equal(t, "0|100000:", fmt.Sprintf("%s", rank))
+	assert.Equal(t, "0|100000:", fmt.Sprintf("%v", rank))
+	assert.Equal(t, `"0|100000:"`, fmt.Sprintf("%q", rank))
+	assert.Equal(t, "0|100000:{bucket:0 integer:100000 fraction: scale:0 length:9 position:0.027778}", fmt.Sprintf("%+v", rank))
+	assert.Equal(t, "%!d(*lexorank.LexoRank=0|100000:)", fmt.Sprintf("%d", rank))
+	assert.Equal(t, "0|100000:   |", fmt.Sprintf("%-12s|", rank))
+	assert.Equal(t, "   0|100000:", fmt.Sprintf("%12v", rank))
+	assert.Equal(t, "0|1", fmt.Sprintf("%.3s", rank))
+	assert.Equal(t, `  "0|100000:"`, fmt.Sprintf("%13q", rank))
+}
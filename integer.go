@@ -3,6 +3,7 @@ package lexorank
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -58,11 +59,13 @@ func makeLexoInteger(system LexoNumeralSystem, sign int, mag []byte) *LexoIntege
 	return NewLexoInteger(system, sign, mag[:actualLength])
 }
 
+// NewLexoInteger copies mag, so later changes by the caller do not leak into
+// the integer.
 func NewLexoInteger(sys LexoNumeralSystem, sign int, mag []byte) *LexoInteger {
 	return &LexoInteger{
 		sys:  sys,
 		sign: sign,
-		mag:  mag,
+		mag:  append([]byte(nil), mag...),
 	}
 }
 
@@ -117,7 +120,7 @@ func (d *LexoInteger) ShiftRight(times int) *LexoInteger {
 	if times < 0 {
 		return d.ShiftLeft(-times)
 	}
-	newMag := d.mag[times:len(d.mag)]
+	newMag := append([]byte(nil), d.mag[times:]...)
 	return makeLexoInteger(d.sys, d.sign, newMag)
 }
 
@@ -310,3 +313,54 @@ func (d *LexoInteger) cmpMag(other *LexoInteger) int {
 	}
 	return 0
 }
+
+func lexoIntegerFromInt(sys LexoNumeralSystem, value int64) *LexoInteger {
+	sign := 1
+	if value < 0 {
+		sign = -1
+		value = -value
+	}
+	base := int64(sys.GetBase())
+	var mag []byte
+	for ; value > 0; value /= base {
+		mag = append(mag, byte(value%base))
+	}
+	return makeLexoInteger(sys, sign, mag)
+}
+
+func (d *LexoInteger) divSmall(divisor int64) *LexoInteger {
+	base := int64(d.sys.GetBase())
+	quotient := make([]byte, len(d.mag))
+	var rem int64
+	for idx := len(d.mag) - 1; idx >= 0; idx-- {
+		cur := rem*base + int64(d.mag[idx])
+		quotient[idx] = byte(cur / divisor)
+		rem = cur % divisor
+	}
+	return makeLexoInteger(d.sys, d.sign, quotient)
+}
+
+func (d *LexoInteger) int64() (int64, bool) {
+	base := int64(d.sys.GetBase())
+	var value int64
+	for idx := len(d.mag) - 1; idx >= 0; idx-- {
+		if value > (math.MaxInt64-int64(d.mag[idx]))/base {
+			return math.MaxInt64 * int64(d.sign), false
+		}
+		value = value*base + int64(d.mag[idx])
+	}
+	return value * int64(d.sign), true
+}
+
+func (d *LexoInteger) log2() float64 {
+	if d.sign <= 0 {
+		return math.Inf(-1)
+	}
+	base := float64(d.sys.GetBase())
+	var top float64
+	digits := 0
+	for idx := len(d.mag) - 1; idx >= 0 && digits < 8; idx, digits = idx-1, digits+1 {
+		top = top*base + float64(d.mag[idx])
+	}
+	return math.Log2(top) + float64(len(d.mag)-digits)*math.Log2(base)
+}
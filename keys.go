@@ -0,0 +1,280 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeyEncoding generates order keys that sort as plain byte strings. An empty
+// prev or next stands for the open start or end of the list.
+type KeyEncoding interface {
+	Between(prev, next string) (string, error)
+	Validate(key string) error
+}
+
+var (
+	_ KeyEncoding = (*LexoRankEncoding)(nil)
+	_ KeyEncoding = (*FractionalIndexEncoding)(nil)
+)
+
+// LexoRankEncoding produces "bucket|integer:fraction" keys with Next and Prev
+// at the open ends.
+type LexoRankEncoding struct {
+	Bucket *LexoRankBucket
+}
+
+func NewLexoRankEncoding(bucket *LexoRankBucket) *LexoRankEncoding {
+	return &LexoRankEncoding{Bucket: bucket}
+}
+
+func (e *LexoRankEncoding) Between(prev, next string) (string, error) {
+	var prevRank, nextRank *LexoRank
+	var err error
+	if prev != "" {
+		if prevRank, err = e.parse(prev); err != nil {
+			return "", err
+		}
+	}
+	if next != "" {
+		if nextRank, err = e.parse(next); err != nil {
+			return "", err
+		}
+	}
+	var rank *LexoRank
+	switch {
+	case prevRank == nil && nextRank == nil:
+		rank = MidLexoRank.InBucket(e.Bucket)
+	case prevRank == nil:
+		rank, err = nextRank.Prev()
+	case nextRank == nil:
+		rank, err = prevRank.Next()
+	default:
+		rank, err = prevRank.Between(nextRank)
+	}
+	if err != nil {
+		return "", err
+	}
+	if prevRank != nil && rank.Compare(prevRank) <= 0 || nextRank != nil && rank.Compare(nextRank) >= 0 {
+		return "", fmt.Errorf("no key between %q and %q", prev, next)
+	}
+	return rank.String(), nil
+}
+
+func (e *LexoRankEncoding) Validate(key string) error {
+	_, err := e.parse(key)
+	return err
+}
+
+func (e *LexoRankEncoding) parse(key string) (*LexoRank, error) {
+	rank, err := LexoRankParse(key)
+	if err != nil {
+		return nil, err
+	}
+	if !rank.bucket.Equals(e.Bucket) {
+		return nil, fmt.Errorf("key %q outside bucket %s", key, e.Bucket)
+	}
+	if rank.String() != key {
+		return nil, fmt.Errorf("key %q not canonical, expected %s", key, rank)
+	}
+	return rank, nil
+}
+
+var fractionalIndexSystem = NewLexoNumeralSystem62()
+
+// FractionalIndexEncoding produces the keys of the fractional-indexing scheme
+// used by collaborative editors: a head letter giving the length of a base-62
+// integer part ("a0", "Zz", "b12"), then optional fraction digits. Appending
+// and prepending stay short for a very long time.
+type FractionalIndexEncoding struct {
+}
+
+func NewFractionalIndexEncoding() *FractionalIndexEncoding {
+	return &FractionalIndexEncoding{}
+}
+
+func (e *FractionalIndexEncoding) Between(prev, next string) (string, error) {
+	if prev != "" {
+		if err := e.Validate(prev); err != nil {
+			return "", err
+		}
+	}
+	if next != "" {
+		if err := e.Validate(next); err != nil {
+			return "", err
+		}
+	}
+	if prev != "" && next != "" && prev >= next {
+		return "", fmt.Errorf("%q is not less than %q", prev, next)
+	}
+	zero := string(fractionalIndexSystem.Char(0))
+	switch {
+	case prev == "" && next == "":
+		return "a" + zero, nil
+	case prev == "":
+		intNext, _ := fractionalInteger(next)
+		fracNext := next[len(intNext):]
+		if intNext == "A"+strings.Repeat(zero, 26) {
+			return intNext + fractionalMidpoint("", fracNext), nil
+		}
+		if intNext < next {
+			return intNext, nil
+		}
+		key, ok := fractionalDecrement(intNext)
+		if !ok {
+			return "", errors.New("cannot decrement any more")
+		}
+		return key, nil
+	case next == "":
+		intPrev, _ := fractionalInteger(prev)
+		if key, ok := fractionalIncrement(intPrev); ok {
+			return key, nil
+		}
+		return intPrev + fractionalMidpoint(prev[len(intPrev):], ""), nil
+	}
+	intPrev, _ := fractionalInteger(prev)
+	intNext, _ := fractionalInteger(next)
+	if intPrev == intNext {
+		return intPrev + fractionalMidpoint(prev[len(intPrev):], next[len(intNext):]), nil
+	}
+	key, ok := fractionalIncrement(intPrev)
+	if !ok {
+		return "", errors.New("cannot increment any more")
+	}
+	if key < next {
+		return key, nil
+	}
+	return intPrev + fractionalMidpoint(prev[len(intPrev):], ""), nil
+}
+
+func (e *FractionalIndexEncoding) Validate(key string) error {
+	zero := fractionalIndexSystem.Char(0)
+	if key == "A"+strings.Repeat(string(zero), 26) {
+		return fmt.Errorf("invalid key: %q", key)
+	}
+	integer, err := fractionalInteger(key)
+	if err != nil {
+		return err
+	}
+	for idx := 1; idx < len(key); idx++ {
+		if _, err := fractionalIndexSystem.Digit(key[idx]); err != nil {
+			return fmt.Errorf("invalid key %q: %w", key, err)
+		}
+	}
+	if len(key) > len(integer) && key[len(key)-1] == zero {
+		return fmt.Errorf("invalid key %q: trailing zero", key)
+	}
+	return nil
+}
+
+func fractionalIntegerLength(head byte) (int, error) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, nil
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, nil
+	}
+	return 0, fmt.Errorf("invalid order key head: %q", head)
+}
+
+func fractionalInteger(key string) (string, error) {
+	if key == "" {
+		return "", errors.New("empty key")
+	}
+	length, err := fractionalIntegerLength(key[0])
+	if err != nil {
+		return "", err
+	}
+	if length > len(key) {
+		return "", fmt.Errorf("invalid order key: %q", key)
+	}
+	return key[:length], nil
+}
+
+// fractionalIncrement adds one to the integer part with LexoInteger arithmetic,
+// moving to the next head letter when the digits overflow.
+func fractionalIncrement(integer string) (string, bool) {
+	head, digits := integer[0], integer[1:]
+	value, _ := LexoIntegerParse(digits, fractionalIndexSystem)
+	value, _ = value.Add(lexoIntegerOne(fractionalIndexSystem))
+	if str := value.String(); len(str) <= len(digits) {
+		return string(head) + strings.Repeat("0", len(digits)-len(str)) + str, true
+	}
+	switch head {
+	case 'Z':
+		return "a0", true
+	case 'z':
+		return "", false
+	}
+	head++
+	if head > 'a' {
+		return string(head) + strings.Repeat("0", len(digits)+1), true
+	}
+	return string(head) + strings.Repeat("0", len(digits)-1), true
+}
+
+func fractionalDecrement(integer string) (string, bool) {
+	head, digits := integer[0], integer[1:]
+	value, _ := LexoIntegerParse(digits, fractionalIndexSystem)
+	if !value.IsZero() {
+		value, _ = value.Sub(lexoIntegerOne(fractionalIndexSystem))
+		str := value.String()
+		return string(head) + strings.Repeat("0", len(digits)-len(str)) + str, true
+	}
+	maxDigit := string(fractionalIndexSystem.Char(fractionalIndexSystem.GetBase() - 1))
+	switch head {
+	case 'a':
+		return "Z" + maxDigit, true
+	case 'A':
+		return "", false
+	}
+	head--
+	if head < 'Z' {
+		return string(head) + strings.Repeat(maxDigit, len(digits)+1), true
+	}
+	return string(head) + strings.Repeat(maxDigit, len(digits)-1), true
+}
+
+// fractionalMidpoint returns a fraction strictly between prev and next, where
+// an empty next means one.
+func fractionalMidpoint(prev, next string) string {
+	zero := fractionalIndexSystem.Char(0)
+	if next != "" {
+		n := 0
+		for ; n < len(next); n++ {
+			prevDigit := zero
+			if n < len(prev) {
+				prevDigit = prev[n]
+			}
+			if prevDigit != next[n] {
+				break
+			}
+		}
+		if n > 0 {
+			if n > len(prev) {
+				return next[:n] + fractionalMidpoint("", next[n:])
+			}
+			return next[:n] + fractionalMidpoint(prev[n:], next[n:])
+		}
+	}
+	digitPrev, digitNext := 0, int(fractionalIndexSystem.GetBase())
+	if prev != "" {
+		d, _ := fractionalIndexSystem.Digit(prev[0])
+		digitPrev = int(d)
+	}
+	if next != "" {
+		d, _ := fractionalIndexSystem.Digit(next[0])
+		digitNext = int(d)
+	}
+	if digitNext-digitPrev > 1 {
+		return string(fractionalIndexSystem.Char(byte((digitPrev + digitNext + 1) / 2)))
+	}
+	if len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(fractionalIndexSystem.Char(byte(digitPrev))) + fractionalMidpoint(rest, "")
+}
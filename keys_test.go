@@ -0,0 +1,103 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFractionalIndexEncoding_Between(t *testing.T) {
+	tests := []struct {
+		prev    string
+		next    string
+		want    string
+		wantErr bool
+	}{
+		{prev: "", next: "", want: "a0"},
+		{prev: "", next: "a0", want: "Zz"},
+		{prev: "", next: "Zz", want: "Zy"},
+		{prev: "a0", next: "", want: "a1"},
+		{prev: "a1", next: "", want: "a2"},
+		{prev: "a0", next: "a1", want: "a0V"},
+		{prev: "a1", next: "a2", want: "a1V"},
+		{prev: "a0V", next: "a1", want: "a0l"},
+		{prev: "Zz", next: "a0", want: "ZzV"},
+		{prev: "Zz", next: "a1", want: "a0"},
+		{prev: "", next: "Y00", want: "Xzzz"},
+		{prev: "bzz", next: "", want: "c000"},
+		{prev: "a0", next: "a0V", want: "a0G"},
+		{prev: "a0", next: "a0G", want: "a08"},
+		{prev: "b125", next: "b129", want: "b127"},
+		{prev: "a0", next: "a1V", want: "a1"},
+		{prev: "Zz", next: "a01", want: "a0"},
+		{prev: "", next: "a0V", want: "a0"},
+		{prev: "", next: "b999", want: "b99"},
+		{prev: "", next: "A000000000000000000000000001", want: "A000000000000000000000000000V"},
+		{prev: "zzzzzzzzzzzzzzzzzzzzzzzzzzy", next: "", want: "zzzzzzzzzzzzzzzzzzzzzzzzzzz"},
+		{prev: "zzzzzzzzzzzzzzzzzzzzzzzzzzz", next: "", want: "zzzzzzzzzzzzzzzzzzzzzzzzzzzV"},
+		{prev: "", next: "A00000000000000000000000000", wantErr: true},
+		{prev: "a00", next: "", wantErr: true},
+		{prev: "a00", next: "a1", wantErr: true},
+		{prev: "0", next: "1", wantErr: true},
+		{prev: "a1", next: "a0", wantErr: true},
+	}
+	encoding := NewFractionalIndexEncoding()
+	for _, tt := range tests {
+		t.Run(tt.prev+"_"+tt.next, func(t *testing.T) {
+			got, err := encoding.Between(tt.prev, tt.next)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "Between(%q, %q)", tt.prev, tt.next)
+		})
+	}
+}
+
+func TestKeyEncoding_Append(t *testing.T) {
+	for name, encoding := range map[string]KeyEncoding{
+		"lexorank":   NewLexoRankEncoding(LexoRankBucket1),
+		"fractional": NewFractionalIndexEncoding(),
+		"unbounded":  NewUnboundedLexoRankEncoding(LexoRankBucket1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			prev := ""
+			for idx := 0; idx < 1000; idx++ {
+				key, err := encoding.Between(prev, "")
+				assert.NoError(t, err)
+				assert.NoError(t, encoding.Validate(key))
+				assert.Less(t, prev, key)
+				prev = key
+			}
+			first, _ := encoding.Between("", "")
+			before, err := encoding.Between("", first)
+			assert.NoError(t, err)
+			mid, err := encoding.Between(before, first)
+			assert.NoError(t, err)
+			assert.Less(t, before, mid)
+			assert.Less(t, mid, first)
+		})
+	}
+}
+
+func TestLexoRankEncoding_Validate(t *testing.T) {
+	encoding := NewLexoRankEncoding(LexoRankBucket0)
+	assert.NoError(t, encoding.Validate("0|hzzzzz:"))
+	assert.Error(t, encoding.Validate("0|hzzzzz"))
+	assert.Error(t, encoding.Validate("1|hzzzzz:"))
+	assert.Error(t, encoding.Validate("a0"))
+}
+
+func TestLexoRankEncoding_Between(t *testing.T) {
+	encoding := NewLexoRankEncoding(LexoRankBucket0)
+	for _, tt := range []struct{ prev, next string }{
+		{prev: "0|zzzzzz:"},
+		{next: "0|000000:"},
+		{prev: "0|100001:", next: "0|100000:"},
+		{prev: "0|100000:", next: "0|100000:"},
+	} {
+		_, err := encoding.Between(tt.prev, tt.next)
+		assert.Errorf(t, err, "Between(%q, %q)", tt.prev, tt.next)
+	}
+}
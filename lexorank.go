@@ -23,6 +23,10 @@ var (
 
 	initialMinDecimal, _ = LexoDecimalParse("100000", LexoRankSystem)
 	initialMaxDecimal, _ = LexoDecimalParse(string(LexoRankSystem.Char(LexoRankSystem.GetBase()-byte(2)))+"00000", LexoRankSystem)
+
+	NilRankErr     = errors.New("nil rank")
+	RankBoundErr   = errors.New("no rank beyond the minimum or maximum")
+	InvalidRankErr = errors.New("invalid rank")
 )
 
 type LexoRank struct {
@@ -31,6 +35,8 @@ type LexoRank struct {
 	decimal *LexoDecimal
 }
 
+// NewLexoRank makes a rank from a decimal in LexoRankSystem. Ranks in other
+// numeral systems are SystemRanks.
 func NewLexoRank(bucket *LexoRankBucket, decimal *LexoDecimal) *LexoRank {
 	return &LexoRank{
 		value:   bucket.String() + "|" + formatDecimal(decimal),
@@ -40,32 +46,68 @@ func NewLexoRank(bucket *LexoRankBucket, decimal *LexoDecimal) *LexoRank {
 }
 
 func formatDecimal(decimal *LexoDecimal) string {
+	sys := decimal.GetSystem()
 	formatVal := decimal.String()
-	partialIndex := strings.Index(formatVal, string(LexoRankSystem.GetRadixPointChar()))
+	partialIndex := strings.IndexByte(formatVal, sys.GetRadixPointChar())
 	if partialIndex < 0 {
 		partialIndex = len(formatVal)
-		formatVal += string(LexoRankSystem.GetRadixPointChar())
+		formatVal += string(sys.GetRadixPointChar())
 	}
-	return strings.Repeat("0", 6-partialIndex) + formatVal
+	return strings.Repeat(string(sys.Char(0)), integerWidth(sys)-partialIndex) + formatVal
 }
 
 func LexoRankParse(str string) (*LexoRank, error) {
-	split := strings.Split(str, "|")
-	if len(split) != 2 {
-		return nil, errors.New("parts not two")
-	}
-	bucket, err := NewLexoRankBucket(split[0])
+	bucket, decimal, err := parseRankParts(str, LexoRankSystem)
 	if err != nil {
-		return nil, fmt.Errorf("lexo rank bucket: %w", err)
+		return nil, err
+	}
+	return NewLexoRank(bucket, decimal), nil
+}
+
+// LexoRankParseStrict parses a rank from untrusted input. Where LexoRankParse
+// pads a short integer part and accepts any bucket, LexoRankParseStrict
+// requires one of LexoRankBuckets, exactly six unsigned digits before the ':'
+// and a rank no greater than the maximum of its bucket. Errors wrap
+// InvalidRankErr.
+func LexoRankParseStrict(str string) (*LexoRank, error) {
+	bucketStr, decimalStr, found := strings.Cut(str, "|")
+	if !found {
+		return nil, fmt.Errorf("%w: %q has no '|'", InvalidRankErr, str)
+	}
+	var bucket *LexoRankBucket
+	for _, b := range LexoRankBuckets {
+		if b.String() == bucketStr {
+			bucket = b
+		}
+	}
+	if bucket == nil {
+		return nil, fmt.Errorf("%w: unknown bucket %q", InvalidRankErr, bucketStr)
+	}
+	integer, fraction, found := strings.Cut(decimalStr, string(LexoRankSystem.GetRadixPointChar()))
+	if !found || len(integer) != integerWidth(LexoRankSystem) {
+		return nil, fmt.Errorf("%w: %q needs %d integer digits and a %q", InvalidRankErr, str, integerWidth(LexoRankSystem), LexoRankSystem.GetRadixPointChar())
 	}
-	decimal, err := LexoDecimalParse(split[1], LexoRankSystem)
+	for _, digits := range []string{integer, fraction} {
+		for idx := 0; idx < len(digits); idx++ {
+			if _, err := LexoRankSystem.Digit(digits[idx]); err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", InvalidRankErr, str, err)
+			}
+		}
+	}
+	rank, err := LexoRankParse(str)
 	if err != nil {
-		return nil, fmt.Errorf("lexo decimal parse: %w", err)
+		return nil, fmt.Errorf("%w: %v", InvalidRankErr, err)
 	}
-	return NewLexoRank(bucket, decimal), nil
+	if rank.Compare(maxLexoRank(bucket)) > 0 {
+		return nil, fmt.Errorf("%w: %s is above %s", InvalidRankErr, rank, maxLexoRank(bucket))
+	}
+	return rank, nil
 }
 
 func (i *LexoRank) Between(other *LexoRank) (*LexoRank, error) {
+	if i == nil || other == nil {
+		return nil, NilRankErr
+	}
 	if !i.bucket.Equals(other.bucket) {
 		return nil, errors.New("between works only within the same bucket")
 	}
@@ -88,9 +130,28 @@ func (i *LexoRank) Between(other *LexoRank) (*LexoRank, error) {
 }
 
 func (i *LexoRank) Prev() (*LexoRank, error) {
+	return i.PrevWith(CompatibilityLegacy)
+}
+
+// PrevWith is Prev under the given compatibility mode.
+func (i *LexoRank) PrevWith(compat Compatibility) (*LexoRank, error) {
+	if i == nil {
+		return nil, NilRankErr
+	}
+	if i.IsMin() {
+		return nil, fmt.Errorf("%w: prev of %s", RankBoundErr, i)
+	}
 	if i.IsMax() {
 		return NewLexoRank(i.bucket, initialMaxDecimal), nil
 	}
+	if compat == CompatibilityJira {
+		floorDecimal := LexoDecimalMake(i.decimal.Floor(), 0)
+		nextDecimal := floorDecimal.Sub(eightDecimal)
+		if nextDecimal.Compare(minDecimal) <= 0 {
+			nextDecimal, _ = minDecimal.Between(i.decimal)
+		}
+		return NewLexoRank(i.bucket, nextDecimal), nil
+	}
 	ceilInteger := i.decimal.Ceil()
 	ceilDecimal := LexoDecimalMake(ceilInteger, 0)
 	nextDecimal := ceilDecimal.Sub(eightDecimal)
@@ -101,6 +162,12 @@ func (i *LexoRank) Prev() (*LexoRank, error) {
 }
 
 func (i *LexoRank) Next() (*LexoRank, error) {
+	if i == nil {
+		return nil, NilRankErr
+	}
+	if i.IsMax() {
+		return nil, fmt.Errorf("%w: next of %s", RankBoundErr, i)
+	}
 	if i.IsMin() {
 		return NewLexoRank(i.bucket, initialMinDecimal), nil
 	}
@@ -114,13 +181,140 @@ func (i *LexoRank) Next() (*LexoRank, error) {
 }
 
 func (i *LexoRank) String() string {
+	if i == nil {
+		return ""
+	}
 	return i.value
 }
 
 func (i *LexoRank) IsMin() bool {
+	if i == nil {
+		return false
+	}
 	return i.decimal.Equals(minDecimal)
 }
 
 func (i *LexoRank) IsMax() bool {
+	if i == nil {
+		return false
+	}
 	return i.decimal.Equals(maxDecimal)
 }
+
+func (i *LexoRank) Compare(other *LexoRank) int {
+	switch {
+	case i == nil && other == nil:
+		return 0
+	case i == nil:
+		return -1
+	case other == nil:
+		return 1
+	}
+	if cmp := i.bucket.value.Compare(other.bucket.value); cmp != 0 {
+		return cmp
+	}
+	return i.decimal.Compare(other.decimal)
+}
+
+func (i *LexoRank) BetweenN(other *LexoRank, n int) ([]*LexoRank, error) {
+	if i == nil || other == nil {
+		return nil, NilRankErr
+	}
+	if !i.bucket.Equals(other.bucket) {
+		return nil, errors.New("between works only within the same bucket")
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative count: %d", n)
+	}
+	left, right := i, other
+	switch cmp := i.decimal.Compare(other.decimal); {
+	case cmp > 0:
+		left, right = other, i
+	case cmp == 0 && n > 0:
+		return nil, fmt.Errorf("try to rank between issues with same rank this=%s other=%s", i.String(), other.String())
+	}
+	decimals := left.decimal.spread(right.decimal, n, 1)
+	ranks := make([]*LexoRank, len(decimals))
+	for idx, decimal := range decimals {
+		ranks[idx] = NewLexoRank(i.bucket, decimal)
+	}
+	return ranks, nil
+}
+
+func minLexoRank(bucket *LexoRankBucket) *LexoRank {
+	return NewLexoRank(bucket, minDecimal)
+}
+
+func maxLexoRank(bucket *LexoRankBucket) *LexoRank {
+	return NewLexoRank(bucket, maxDecimal)
+}
+
+func maxScale(bucket *LexoRankBucket, maxLength int) int {
+	return maxLength - len(NewLexoRank(bucket, zeroDecimal).String())
+}
+
+func (i *LexoRank) Distance(other *LexoRank) (*LexoDecimal, error) {
+	if i == nil || other == nil {
+		return nil, NilRankErr
+	}
+	if !i.bucket.Equals(other.bucket) {
+		return nil, errors.New("distance works only within the same bucket")
+	}
+	if i.decimal.Compare(other.decimal) > 0 {
+		return i.decimal.Sub(other.decimal), nil
+	}
+	return other.decimal.Sub(i.decimal), nil
+}
+
+func (i *LexoRank) Capacity(other *LexoRank, maxLength int) (int64, error) {
+	if i == nil || other == nil {
+		return 0, NilRankErr
+	}
+	if !i.bucket.Equals(other.bucket) {
+		return 0, errors.New("capacity works only within the same bucket")
+	}
+	return i.decimal.Capacity(other.decimal, maxScale(i.bucket, maxLength)), nil
+}
+
+func (i *LexoRank) InBucket(bucket *LexoRankBucket) *LexoRank {
+	if i == nil {
+		return nil
+	}
+	return NewLexoRank(bucket, i.decimal)
+}
+
+// LexoRankSpread yields the same ranks as BetweenN one at a time, for lists too
+// long to hold in memory.
+type LexoRankSpread struct {
+	bucket *LexoRankBucket
+	spread *decimalSpread
+	next   int
+}
+
+func NewLexoRankSpread(low, high *LexoRank, n int) (*LexoRankSpread, error) {
+	if low == nil || high == nil {
+		return nil, NilRankErr
+	}
+	if !low.bucket.Equals(high.bucket) {
+		return nil, errors.New("spread works only within the same bucket")
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative count: %d", n)
+	}
+	if low.decimal.Compare(high.decimal) >= 0 && n > 0 {
+		return nil, fmt.Errorf("empty range low=%s high=%s", low.String(), high.String())
+	}
+	return &LexoRankSpread{
+		bucket: low.bucket,
+		spread: newDecimalSpread(low.decimal, high.decimal, n, 1),
+	}, nil
+}
+
+func (s *LexoRankSpread) Next() (*LexoRank, bool) {
+	if s.next >= s.spread.n {
+		return nil, false
+	}
+	rank := NewLexoRank(s.bucket, s.spread.at(s.next))
+	s.next++
+	return rank, true
+}
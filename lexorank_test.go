@@ -4,8 +4,19 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func parseRanks(t *testing.T, values ...string) []*LexoRank {
+	ranks := make([]*LexoRank, len(values))
+	for idx, value := range values {
+		rank, err := LexoRankParse(value)
+		require.NoError(t, err)
+		ranks[idx] = rank
+	}
+	return ranks
+}
+
 func TestLexoRankBetween(t *testing.T) {
 	minNext, _ := MinLexoRank.Next()
 	maxPrev, _ := MaxLexoRank.Prev()
@@ -93,6 +104,27 @@ func TestLexoRankParse(t *testing.T) {
 	}
 }
 
+func TestLexoRankParse_TooWide(t *testing.T) {
+	_, err := LexoRankParse("0|1000000:")
+	assert.Error(t, err)
+}
+
+func TestLexoRankParseStrict(t *testing.T) {
+	for _, value := range []string{"0|000000:", "0|hzzzzz:i", "1|zzzzzz:", "2|i00000:001"} {
+		rank, err := LexoRankParseStrict(value)
+		if assert.NoErrorf(t, err, "LexoRankParseStrict(%q)", value) {
+			assert.Equal(t, value, rank.String())
+		}
+	}
+	for _, value := range []string{
+		"", "0", "x|000000:", "9|000000:", "3|000000:", "0|1000000:", "0|zzzzzz:1",
+		"0|hzzzzz", "0|i", "2|0:1", "0|-00000:", "0|+00000:", "0|000000:-1", "0|00000A:",
+	} {
+		_, err := LexoRankParseStrict(value)
+		assert.ErrorIsf(t, err, InvalidRankErr, "LexoRankParseStrict(%q)", value)
+	}
+}
+
 func TestLexoRank_Between(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -183,3 +215,142 @@ func TestLexoRank_Next(t *testing.T) {
 		})
 	}
 }
+
+func TestLexoRank_Bounds(t *testing.T) {
+	_, err := MaxLexoRank.Next()
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = MinLexoRank.Prev()
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = MinLexoRank.PrevWith(CompatibilityJira)
+	assert.ErrorIs(t, err, RankBoundErr)
+
+	next, err := MinLexoRank.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "0|100000:", next.String())
+	prev, err := MaxLexoRank.Prev()
+	assert.NoError(t, err)
+	assert.Equal(t, "0|y00000:", prev.String())
+}
+
+func TestLexoRank_BetweenN(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		n     int
+		want  []string
+	}{
+		{
+			name:  "integer",
+			left:  "0|100000:",
+			right: "0|100004:",
+			n:     3,
+			want:  []string{"0|100001:", "0|100002:", "0|100003:"},
+		},
+		{
+			name:  "decimal",
+			left:  "0|100000:",
+			right: "0|100001:",
+			n:     2,
+			want:  []string{"0|100000:c", "0|100000:o"},
+		},
+		{
+			name:  "reversed",
+			left:  "0|100004:",
+			right: "0|100000:",
+			n:     1,
+			want:  []string{"0|100002:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, _ := LexoRankParse(tt.left)
+			right, _ := LexoRankParse(tt.right)
+			got, err := left.BetweenN(right, tt.n)
+			assert.NoError(t, err)
+			values := make([]string, len(got))
+			for idx, rank := range got {
+				values[idx] = rank.String()
+			}
+			assert.Equalf(t, tt.want, values, "BetweenN(%v, %d)", tt.right, tt.n)
+		})
+	}
+}
+
+func TestLexoRank_Capacity(t *testing.T) {
+	tests := []struct {
+		name      string
+		left      string
+		right     string
+		maxLength int
+		want      int64
+		distance  string
+	}{
+		{
+			name:      "integers",
+			left:      "0|100000:",
+			right:     "0|100004:",
+			maxLength: 9,
+			want:      3,
+			distance:  "4",
+		},
+		{
+			name:      "adjacent",
+			left:      "0|100001:",
+			right:     "0|100000:",
+			maxLength: 9,
+			want:      0,
+			distance:  "1",
+		},
+		{
+			name:      "one more digit",
+			left:      "0|100000:",
+			right:     "0|100001:",
+			maxLength: 10,
+			want:      35,
+			distance:  "1",
+		},
+		{
+			name:      "decimal",
+			left:      "0|100000:i",
+			right:     "0|100001:",
+			maxLength: 11,
+			want:      647,
+			distance:  "0:i",
+		},
+		{
+			name:      "too short",
+			left:      "0|100000:",
+			right:     "0|100004:",
+			maxLength: 8,
+			want:      0,
+			distance:  "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, _ := LexoRankParse(tt.left)
+			right, _ := LexoRankParse(tt.right)
+			got, err := left.Capacity(right, tt.maxLength)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "Capacity(%v, %d)", tt.right, tt.maxLength)
+			distance, err := left.Distance(right)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.distance, distance.String(), "Distance(%v)", tt.right)
+		})
+	}
+}
+
+func TestLexoRankSpread(t *testing.T) {
+	want, _ := MinLexoRank.BetweenN(MaxLexoRank, 5)
+	spread, err := NewLexoRankSpread(MinLexoRank, MaxLexoRank, 5)
+	assert.NoError(t, err)
+	var got []*LexoRank
+	for rank, ok := spread.Next(); ok; rank, ok = spread.Next() {
+		got = append(got, rank)
+	}
+	assert.Equal(t, want, got)
+
+	_, err = NewLexoRankSpread(MaxLexoRank, MinLexoRank, 5)
+	assert.Error(t, err)
+}
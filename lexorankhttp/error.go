@@ -0,0 +1,38 @@
+package lexorankhttp
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error carries the HTTP status and machine-readable code for a failed request.
+type Error struct {
+	Status int
+	Code   string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func StatusOf(err error) int {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Status
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	resp := ErrorResponse{Code: "internal", Error: err.Error()}
+	var typed *Error
+	if errors.As(err, &typed) {
+		resp = ErrorResponse{Code: typed.Code, Error: typed.Err.Error()}
+	}
+	writeJSON(w, StatusOf(err), resp)
+}
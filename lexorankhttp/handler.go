@@ -0,0 +1,265 @@
+// Package lexorankhttp serves the LexoRank API as JSON over HTTP, so services in
+// other languages get byte-for-byte the same ranks as Go callers.
+package lexorankhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+const (
+	DefaultMaxBodyBytes = 1 << 20
+	DefaultMaxSpread    = 10000
+	DefaultMaxValidate  = 10000
+)
+
+type Config struct {
+	MaxBodyBytes int64
+	MaxSpread    int
+	MaxValidate  int
+}
+
+type Handler struct {
+	cfg Config
+	mux *http.ServeMux
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+func NewHandler(cfg Config) *Handler {
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if cfg.MaxSpread <= 0 {
+		cfg.MaxSpread = DefaultMaxSpread
+	}
+	if cfg.MaxValidate <= 0 {
+		cfg.MaxValidate = DefaultMaxValidate
+	}
+	h := &Handler{cfg: cfg, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/between", h.post(h.between))
+	h.mux.HandleFunc("/next", h.post(h.next))
+	h.mux.HandleFunc("/prev", h.post(h.prev))
+	h.mux.HandleFunc("/spread", h.post(h.spread))
+	h.mux.HandleFunc("/validate", h.post(h.validate))
+	h.mux.HandleFunc("/healthz", h.health)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+type BetweenRequest struct {
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+type StepRequest struct {
+	Rank string `json:"rank"`
+}
+
+type SpreadRequest struct {
+	Count  int    `json:"count"`
+	Bucket string `json:"bucket,omitempty"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+type ValidateRequest struct {
+	Ranks []string `json:"ranks"`
+}
+
+type RankResponse struct {
+	Rank string `json:"rank"`
+}
+
+type SpreadResponse struct {
+	Ranks []string `json:"ranks"`
+}
+
+type ValidateResult struct {
+	Rank      string `json:"rank"`
+	Valid     bool   `json:"valid"`
+	Canonical string `json:"canonical,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ValidateResponse struct {
+	Results []ValidateResult `json:"results"`
+}
+
+type ErrorResponse struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+func (h *Handler) between(r *http.Request) (any, error) {
+	var req BetweenRequest
+	if err := h.decode(r, &req); err != nil {
+		return nil, err
+	}
+	left, err := parseRank("left", req.Left)
+	if err != nil {
+		return nil, err
+	}
+	right, err := parseRank("right", req.Right)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := left.Between(right)
+	if err != nil {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "between_failed", Err: err}
+	}
+	return RankResponse{Rank: rank.String()}, nil
+}
+
+func (h *Handler) next(r *http.Request) (any, error) {
+	return h.step(r, (*lexorank.LexoRank).Next)
+}
+
+func (h *Handler) prev(r *http.Request) (any, error) {
+	return h.step(r, (*lexorank.LexoRank).Prev)
+}
+
+func (h *Handler) step(r *http.Request, step func(*lexorank.LexoRank) (*lexorank.LexoRank, error)) (any, error) {
+	var req StepRequest
+	if err := h.decode(r, &req); err != nil {
+		return nil, err
+	}
+	rank, err := parseRank("rank", req.Rank)
+	if err != nil {
+		return nil, err
+	}
+	if rank, err = step(rank); err != nil {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "step_failed", Err: err}
+	}
+	return RankResponse{Rank: rank.String()}, nil
+}
+
+func (h *Handler) spread(r *http.Request) (any, error) {
+	var req SpreadRequest
+	if err := h.decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Count < 0 || req.Count > h.cfg.MaxSpread {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "count_out_of_range",
+			Err: fmt.Errorf("count must be between 0 and %d", h.cfg.MaxSpread)}
+	}
+	bucket := lexorank.LexoRankBucket0
+	if req.Bucket != "" {
+		bucket = nil
+		for _, b := range lexorank.LexoRankBuckets {
+			if b.String() == req.Bucket {
+				bucket = b
+			}
+		}
+		if bucket == nil {
+			return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "invalid_bucket", Err: fmt.Errorf("unknown bucket: %q", req.Bucket)}
+		}
+	}
+	low, high := lexorank.MinLexoRank.InBucket(bucket), lexorank.MaxLexoRank.InBucket(bucket)
+	var err error
+	if req.From != "" {
+		if low, err = parseRank("from", req.From); err != nil {
+			return nil, err
+		}
+	}
+	if req.To != "" {
+		if high, err = parseRank("to", req.To); err != nil {
+			return nil, err
+		}
+	}
+	ranks, err := low.BetweenN(high, req.Count)
+	if err != nil {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "spread_failed", Err: err}
+	}
+	resp := SpreadResponse{Ranks: make([]string, len(ranks))}
+	for idx, rank := range ranks {
+		resp.Ranks[idx] = rank.String()
+	}
+	return resp, nil
+}
+
+func (h *Handler) validate(r *http.Request) (any, error) {
+	var req ValidateRequest
+	if err := h.decode(r, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Ranks) > h.cfg.MaxValidate {
+		return nil, &Error{Status: http.StatusRequestEntityTooLarge, Code: "too_many_ranks",
+			Err: fmt.Errorf("at most %d ranks per request", h.cfg.MaxValidate)}
+	}
+	resp := ValidateResponse{Results: make([]ValidateResult, len(req.Ranks))}
+	for idx, value := range req.Ranks {
+		result := ValidateResult{Rank: value}
+		rank, err := lexorank.LexoRankParseStrict(value)
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+		case rank.String() != value:
+			result.Canonical = rank.String()
+			result.Error = "not canonical"
+		default:
+			result.Valid = true
+			result.Canonical = rank.String()
+		}
+		resp.Results[idx] = result
+	}
+	return resp, nil
+}
+
+func (h *Handler) health(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, &Error{Status: http.StatusMethodNotAllowed, Code: "method_not_allowed", Err: errors.New(r.Method)})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (h *Handler) post(handle func(*http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, &Error{Status: http.StatusMethodNotAllowed, Code: "method_not_allowed", Err: errors.New(r.Method)})
+			return
+		}
+		resp, err := handle(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func (h *Handler) decode(r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, h.cfg.MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &Error{Status: http.StatusRequestEntityTooLarge, Code: "body_too_large", Err: err}
+		}
+		return &Error{Status: http.StatusBadRequest, Code: "invalid_json", Err: err}
+	}
+	return nil
+}
+
+func parseRank(field, value string) (*lexorank.LexoRank, error) {
+	rank, err := lexorank.LexoRankParseStrict(value)
+	if err != nil {
+		return nil, &Error{Status: http.StatusUnprocessableEntity, Code: "invalid_rank", Err: fmt.Errorf("%s: %w", field, err)}
+	}
+	return rank, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
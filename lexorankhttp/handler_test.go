@@ -0,0 +1,154 @@
+package lexorankhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "between",
+			method:     http.MethodPost,
+			path:       "/between",
+			body:       `{"left":"0|100000:","right":"0|100001:"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"rank":"0|100000:i"}`,
+		},
+		{
+			name:       "between same rank",
+			method:     http.MethodPost,
+			path:       "/between",
+			body:       `{"left":"0|100000:","right":"0|100000:"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"between_failed","error":"try to rank between issues with same rank this=0|100000: other=0|100000:"}`,
+		},
+		{
+			name:       "next",
+			method:     http.MethodPost,
+			path:       "/next",
+			body:       `{"rank":"0|hzzzzz:"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"rank":"0|i00007:"}`,
+		},
+		{
+			name:       "prev invalid rank",
+			method:     http.MethodPost,
+			path:       "/prev",
+			body:       `{"rank":"nope"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"invalid_rank","error":"rank: invalid rank: \"nope\" has no '|'"}`,
+		},
+		{
+			name:       "spread",
+			method:     http.MethodPost,
+			path:       "/spread",
+			body:       `{"count":3,"bucket":"1"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ranks":["1|8zzzzz:","1|hzzzzz:","1|qzzzzz:"]}`,
+		},
+		{
+			name:       "spread too many",
+			method:     http.MethodPost,
+			path:       "/spread",
+			body:       `{"count":11}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"count_out_of_range","error":"count must be between 0 and 10"}`,
+		},
+		{
+			name:       "validate",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"ranks":["0|i00000:","0|i00000:0","x"]}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"results":[{"rank":"0|i00000:","valid":true,"canonical":"0|i00000:"},` +
+				`{"rank":"0|i00000:0","valid":false,"canonical":"0|i00000:","error":"not canonical"},` +
+				`{"rank":"x","valid":false,"error":"invalid rank: \"x\" has no '|'"}]}`,
+		},
+		{
+			name:       "validate strict",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"ranks":["x|000000:","9|000000:","0|zzzzzz:1","0|1000000:","0|i"]}`,
+			wantStatus: http.StatusOK,
+			wantBody: `{"results":[{"rank":"x|000000:","valid":false,"error":"invalid rank: unknown bucket \"x\""},` +
+				`{"rank":"9|000000:","valid":false,"error":"invalid rank: unknown bucket \"9\""},` +
+				`{"rank":"0|zzzzzz:1","valid":false,"error":"invalid rank: 0|zzzzzz:1 is above 0|zzzzzz:"},` +
+				`{"rank":"0|1000000:","valid":false,"error":"invalid rank: \"0|1000000:\" needs 6 integer digits and a ':'"},` +
+				`{"rank":"0|i","valid":false,"error":"invalid rank: \"0|i\" needs 6 integer digits and a ':'"}]}`,
+		},
+		{
+			name:       "next above max",
+			method:     http.MethodPost,
+			path:       "/next",
+			body:       `{"rank":"0|zzzzzz:1"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"invalid_rank","error":"rank: invalid rank: 0|zzzzzz:1 is above 0|zzzzzz:"}`,
+		},
+		{
+			name:       "between too wide",
+			method:     http.MethodPost,
+			path:       "/between",
+			body:       `{"left":"0|1000000:","right":"0|100001:"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"invalid_rank","error":"left: invalid rank: \"0|1000000:\" needs 6 integer digits and a ':'"}`,
+		},
+		{
+			name:       "spread unknown bucket in range",
+			method:     http.MethodPost,
+			path:       "/spread",
+			body:       `{"count":1,"from":"3|000000:","to":"3|000001:"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"code":"invalid_rank","error":"from: invalid rank: unknown bucket \"3\""}`,
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"ranks":["` + strings.Repeat("0", 300) + `"]}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "invalid json",
+			method:     http.MethodPost,
+			path:       "/next",
+			body:       `{"rank":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			path:       "/between",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "health",
+			method:     http.MethodGet,
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"status":"ok"}`,
+		},
+	}
+	handler := NewHandler(Config{MaxBodyBytes: 256, MaxSpread: 10})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, recorder.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/LEXASOFT/LexoRank/lexoranksql
+
+go 1.19
+
+require (
+	github.com/LEXASOFT/LexoRank v0.0.0-20261019074618-de93a71483b4
+	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/stretchr/testify v1.8.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,360 @@
+// Package lexoranksql keeps a LexoRank column ordered inside a database/sql
+// table. Every operation runs in its own transaction and reads the neighbors it
+// ranks between inside that transaction. The table must have a UNIQUE
+// constraint on (partition, rank): a concurrent writer that computes the same
+// rank breaks it, and the operation fails with ConflictErr instead of sharing
+// the rank.
+package lexoranksql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+var (
+	ConflictErr = errors.New("rank conflict, retry the operation")
+	NotFoundErr = errors.New("row not found")
+)
+
+// Table describes the ordered table. Names are inserted into queries verbatim
+// and must come from trusted configuration.
+type Table struct {
+	Name            string
+	IDColumn        string
+	RankColumn      string
+	PartitionColumn string
+	// LockClause is appended to neighbor reads, e.g. "FOR UPDATE". Leave it
+	// empty for SQLite, which locks the whole database on write.
+	LockClause string
+	// Placeholder renders the n-th (1-based) bind parameter. Defaults to "?".
+	Placeholder func(n int) string
+	// Retries is how many times an operation is repeated after ConflictErr.
+	Retries int
+	// UniqueViolation reports whether an INSERT or UPDATE error comes from the
+	// UNIQUE constraint on the rank. Defaults to matching the messages of
+	// SQLite, PostgreSQL and MySQL.
+	UniqueViolation func(error) bool
+}
+
+type Repository struct {
+	db    *sql.DB
+	table Table
+}
+
+func NewRepository(db *sql.DB, table Table) *Repository {
+	if table.Placeholder == nil {
+		table.Placeholder = func(int) string { return "?" }
+	}
+	if table.UniqueViolation == nil {
+		table.UniqueViolation = isUniqueViolation
+	}
+	return &Repository{db: db, table: table}
+}
+
+// MoveBefore ranks id directly before beforeID and returns the new rank.
+func (r *Repository) MoveBefore(ctx context.Context, partition, id, beforeID any) (*lexorank.LexoRank, error) {
+	var rank *lexorank.LexoRank
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		anchor, err := r.rankOf(ctx, tx, partition, beforeID)
+		if err != nil {
+			return err
+		}
+		prev, err := r.neighbor(ctx, tx, partition, id, anchor, "<", "DESC")
+		if err != nil {
+			return err
+		}
+		if prev == nil {
+			prev = lexorank.MinLexoRank.InBucket(anchor.GetBucket())
+		}
+		rank, err = r.place(ctx, tx, partition, id, prev, anchor)
+		return err
+	})
+	return rank, err
+}
+
+// MoveAfter ranks id directly after afterID and returns the new rank.
+func (r *Repository) MoveAfter(ctx context.Context, partition, id, afterID any) (*lexorank.LexoRank, error) {
+	var rank *lexorank.LexoRank
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		anchor, err := r.rankOf(ctx, tx, partition, afterID)
+		if err != nil {
+			return err
+		}
+		next, err := r.neighbor(ctx, tx, partition, id, anchor, ">", "ASC")
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			next = lexorank.MaxLexoRank.InBucket(anchor.GetBucket())
+		}
+		rank, err = r.place(ctx, tx, partition, id, anchor, next)
+		return err
+	})
+	return rank, err
+}
+
+// InsertAt inserts a new row with id at the zero-based index and returns its
+// rank. An index past the end appends.
+func (r *Repository) InsertAt(ctx context.Context, partition, id any, index int) (*lexorank.LexoRank, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("negative index: %d", index)
+	}
+	var rank *lexorank.LexoRank
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		offset, limit := index-1, 2
+		if index == 0 {
+			offset, limit = 0, 1
+		}
+		where, args := r.partitionWhere(partition, 1)
+		query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT %d OFFSET %d%s",
+			r.table.RankColumn, r.table.Name, where, r.table.RankColumn, limit, offset, r.lockClause())
+		ranks, err := r.queryRanks(ctx, tx, query, args...)
+		if err != nil {
+			return err
+		}
+		prev, next := lexorank.MinLexoRank, lexorank.MaxLexoRank
+		switch {
+		case index == 0 && len(ranks) == 1:
+			prev, next = lexorank.MinLexoRank.InBucket(ranks[0].GetBucket()), ranks[0]
+		case len(ranks) == 2:
+			prev, next = ranks[0], ranks[1]
+		case len(ranks) == 1:
+			prev, next = ranks[0], lexorank.MaxLexoRank.InBucket(ranks[0].GetBucket())
+		case index > 0:
+			last, err := r.last(ctx, tx, partition)
+			if err != nil {
+				return err
+			}
+			if last != nil {
+				prev, next = last, lexorank.MaxLexoRank.InBucket(last.GetBucket())
+			}
+		}
+		if rank, err = prev.Between(next); err != nil {
+			return err
+		}
+		columns := []string{r.table.IDColumn, r.table.RankColumn}
+		values := []any{id, rank.String()}
+		if r.table.PartitionColumn != "" {
+			columns = append(columns, r.table.PartitionColumn)
+			values = append(values, partition)
+		}
+		placeholders := make([]string, len(values))
+		for idx := range placeholders {
+			placeholders[idx] = r.table.Placeholder(idx + 1)
+		}
+		query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			r.table.Name, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+		if _, err := tx.ExecContext(ctx, query, values...); err != nil {
+			return r.writeErr("insert", err)
+		}
+		return nil
+	})
+	return rank, err
+}
+
+// Rebalance rewrites every rank in the partition with evenly spaced ranks in
+// the bucket of the first row, keeping the current order (ties broken by id).
+// Rows first get a temporary value, the new rank followed by '~', so that no
+// new rank collides with an old one still in the table.
+func (r *Repository) Rebalance(ctx context.Context, partition any) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		where, args := r.partitionWhere(partition, 1)
+		query := fmt.Sprintf("SELECT %s, %s FROM %s%s ORDER BY %s, %s%s",
+			r.table.IDColumn, r.table.RankColumn, r.table.Name, where,
+			r.table.RankColumn, r.table.IDColumn, r.lockClause())
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("select: %w", err)
+		}
+		var ids []any
+		bucket := lexorank.LexoRankBucket0
+		for rows.Next() {
+			var id any
+			var value string
+			if err := rows.Scan(&id, &value); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan: %w", err)
+			}
+			if rank, err := lexorank.LexoRankParse(value); err == nil && len(ids) == 0 {
+				bucket = rank.GetBucket()
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("select: %w", err)
+		}
+		spread, err := lexorank.NewLexoRankSpread(lexorank.MinLexoRank.InBucket(bucket), lexorank.MaxLexoRank.InBucket(bucket), len(ids))
+		if err != nil {
+			return err
+		}
+		values := make([]string, len(ids))
+		for idx, id := range ids {
+			rank, _ := spread.Next()
+			values[idx] = rank.String()
+			if err := r.update(ctx, tx, partition, id, values[idx]+"~"); err != nil {
+				return err
+			}
+		}
+		for idx, id := range ids {
+			if err := r.update(ctx, tx, partition, id, values[idx]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) place(ctx context.Context, tx *sql.Tx, partition, id any, prev, next *lexorank.LexoRank) (*lexorank.LexoRank, error) {
+	rank, err := prev.Between(next)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.update(ctx, tx, partition, id, rank.String()); err != nil {
+		return nil, err
+	}
+	return rank, nil
+}
+
+func (r *Repository) rankOf(ctx context.Context, tx *sql.Tx, partition, id any) (*lexorank.LexoRank, error) {
+	where, args := r.partitionWhere(partition, 2)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s%s%s",
+		r.table.RankColumn, r.table.Name, r.table.IDColumn, r.table.Placeholder(1),
+		strings.Replace(where, " WHERE ", " AND ", 1), r.lockClause())
+	ranks, err := r.queryRanks(ctx, tx, query, append([]any{id}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranks) == 0 {
+		return nil, fmt.Errorf("%w: %v", NotFoundErr, id)
+	}
+	return ranks[0], nil
+}
+
+func (r *Repository) neighbor(ctx context.Context, tx *sql.Tx, partition, id any, anchor *lexorank.LexoRank, op, order string) (*lexorank.LexoRank, error) {
+	where, args := r.partitionWhere(partition, 3)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s %s %s AND %s <> %s%s ORDER BY %s %s LIMIT 1%s",
+		r.table.RankColumn, r.table.Name,
+		r.table.RankColumn, op, r.table.Placeholder(1),
+		r.table.IDColumn, r.table.Placeholder(2),
+		strings.Replace(where, " WHERE ", " AND ", 1),
+		r.table.RankColumn, order, r.lockClause())
+	ranks, err := r.queryRanks(ctx, tx, query, append([]any{anchor.String(), id}, args...)...)
+	if err != nil || len(ranks) == 0 {
+		return nil, err
+	}
+	return ranks[0], nil
+}
+
+func (r *Repository) last(ctx context.Context, tx *sql.Tx, partition any) (*lexorank.LexoRank, error) {
+	where, args := r.partitionWhere(partition, 1)
+	query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s DESC LIMIT 1%s",
+		r.table.RankColumn, r.table.Name, where, r.table.RankColumn, r.lockClause())
+	ranks, err := r.queryRanks(ctx, tx, query, args...)
+	if err != nil || len(ranks) == 0 {
+		return nil, err
+	}
+	return ranks[0], nil
+}
+
+func (r *Repository) update(ctx context.Context, tx *sql.Tx, partition, id any, rank string) error {
+	where, args := r.partitionWhere(partition, 3)
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s%s",
+		r.table.Name, r.table.RankColumn, r.table.Placeholder(1),
+		r.table.IDColumn, r.table.Placeholder(2), strings.Replace(where, " WHERE ", " AND ", 1))
+	result, err := tx.ExecContext(ctx, query, append([]any{rank, id}, args...)...)
+	if err != nil {
+		return r.writeErr("update", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("%w: %v", NotFoundErr, id)
+	}
+	return nil
+}
+
+// writeErr turns a violation of the UNIQUE constraint into ConflictErr, as a
+// concurrent writer took the rank first.
+func (r *Repository) writeErr(op string, err error) error {
+	if r.table.UniqueViolation(err) {
+		return fmt.Errorf("%w: %s: %v", ConflictErr, op, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	for _, marker := range []string{
+		"UNIQUE constraint failed",                       // SQLite
+		"SQLSTATE 23505",                                 // PostgreSQL (pgx)
+		"duplicate key value violates unique constraint", // PostgreSQL
+		"Error 1062",                                     // MySQL
+		"Duplicate entry",                                // MySQL
+	} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Repository) queryRanks(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]*lexorank.LexoRank, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select: %w", err)
+	}
+	defer rows.Close()
+	var ranks []*lexorank.LexoRank
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		rank, err := lexorank.LexoRankParse(value)
+		if err != nil {
+			return nil, fmt.Errorf("stored rank %q: %w", value, err)
+		}
+		ranks = append(ranks, rank)
+	}
+	return ranks, rows.Err()
+}
+
+func (r *Repository) partitionWhere(partition any, placeholder int) (string, []any) {
+	if r.table.PartitionColumn == "" {
+		return "", nil
+	}
+	return fmt.Sprintf(" WHERE %s = %s", r.table.PartitionColumn, r.table.Placeholder(placeholder)), []any{partition}
+}
+
+func (r *Repository) lockClause() string {
+	if r.table.LockClause == "" {
+		return ""
+	}
+	return " " + r.table.LockClause
+}
+
+func (r *Repository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	var err error
+	for attempt := 0; attempt <= r.table.Retries; attempt++ {
+		var tx *sql.Tx
+		if tx, err = r.db.BeginTx(ctx, nil); err != nil {
+			return fmt.Errorf("begin: %w", err)
+		}
+		if err = fn(tx); err != nil {
+			_ = tx.Rollback()
+			if errors.Is(err, ConflictErr) {
+				continue
+			}
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return fmt.Errorf("commit: %w", err)
+		}
+		return nil
+	}
+	return err
+}
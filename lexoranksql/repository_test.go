@@ -0,0 +1,140 @@
+package lexoranksql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "test.db") + "?_txlock=immediate&_busy_timeout=5000"
+	db, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`CREATE TABLE cards (id TEXT PRIMARY KEY, board INTEGER NOT NULL, rank TEXT NOT NULL, UNIQUE (board, rank))`)
+	require.NoError(t, err)
+	return db
+}
+
+func order(t *testing.T, db *sql.DB, board int) []string {
+	rows, err := db.Query(`SELECT id FROM cards WHERE board = ? ORDER BY rank`, board)
+	require.NoError(t, err)
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func newTestRepository(db *sql.DB) *Repository {
+	return NewRepository(db, Table{Name: "cards", IDColumn: "id", RankColumn: "rank", PartitionColumn: "board", Retries: 3})
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := newTestRepository(db)
+
+	for idx, id := range []string{"a", "b", "c"} {
+		_, err := repo.InsertAt(ctx, 1, id, idx)
+		require.NoError(t, err)
+	}
+	_, err := repo.InsertAt(ctx, 2, "other", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, order(t, db, 1))
+
+	rank, err := repo.InsertAt(ctx, 1, "top", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0|8zzzzz:", rank.String())
+	_, err = repo.InsertAt(ctx, 1, "middle", 2)
+	require.NoError(t, err)
+	_, err = repo.InsertAt(ctx, 1, "end", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"top", "a", "middle", "b", "c", "end"}, order(t, db, 1))
+
+	_, err = repo.MoveBefore(ctx, 1, "end", "top")
+	require.NoError(t, err)
+	_, err = repo.MoveAfter(ctx, 1, "top", "c")
+	require.NoError(t, err)
+	_, err = repo.MoveAfter(ctx, 1, "a", "top")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"end", "middle", "b", "c", "top", "a"}, order(t, db, 1))
+
+	_, err = repo.MoveAfter(ctx, 1, "a", "missing")
+	assert.ErrorIs(t, err, NotFoundErr)
+	_, err = repo.MoveAfter(ctx, 1, "other", "a")
+	assert.ErrorIs(t, err, NotFoundErr)
+
+	require.NoError(t, repo.Rebalance(ctx, 1))
+	assert.Equal(t, []string{"end", "middle", "b", "c", "top", "a"}, order(t, db, 1))
+	var rank0 string
+	require.NoError(t, db.QueryRow(`SELECT rank FROM cards WHERE id = 'end'`).Scan(&rank0))
+	assert.Equal(t, "0|555555:", rank0)
+	assert.Equal(t, []string{"other"}, order(t, db, 2))
+}
+
+func TestRepository_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := newTestRepository(db)
+	_, err := repo.InsertAt(ctx, 1, "anchor", 0)
+	require.NoError(t, err)
+	for idx := 0; idx < 20; idx++ {
+		_, err := repo.InsertAt(ctx, 1, fmt.Sprint("card", idx), idx+1)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	for idx := 0; idx < 20; idx++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			_, err := repo.MoveAfter(ctx, 1, id, "anchor")
+			assert.NoError(t, err)
+		}(fmt.Sprint("card", idx))
+	}
+	wg.Wait()
+
+	var duplicates int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) - COUNT(DISTINCT rank) FROM cards`).Scan(&duplicates))
+	assert.Zero(t, duplicates)
+	assert.Len(t, order(t, db, 1), 21)
+}
+
+func TestRepository_UniqueViolation(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	_, err := db.Exec(`CREATE TABLE tiles (id TEXT PRIMARY KEY, board INTEGER NOT NULL, rank TEXT NOT NULL UNIQUE)`)
+	require.NoError(t, err)
+	repo := NewRepository(db, Table{Name: "tiles", IDColumn: "id", RankColumn: "rank", PartitionColumn: "board", Retries: 1})
+
+	_, err = repo.InsertAt(ctx, 1, "a", 0)
+	require.NoError(t, err)
+	// Board 2 does not see board 1, computes the same first rank and hits the
+	// constraint, as a concurrent writer would.
+	_, err = repo.InsertAt(ctx, 2, "b", 0)
+	assert.ErrorIs(t, err, ConflictErr)
+}
+
+func TestRepository_BetweenError(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	_, err := db.Exec(`INSERT INTO cards (id, board, rank) VALUES ('min', 1, '0|000000:'), ('x', 1, '0|hzzzzz:')`)
+	require.NoError(t, err)
+	repo := newTestRepository(db)
+
+	// Nothing fits below the minimum rank; retrying cannot help.
+	_, err = repo.MoveBefore(ctx, 1, "x", "min")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ConflictErr)
+}
@@ -0,0 +1,84 @@
+package lexoranktest
+
+import (
+	"sync"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+var _ lexorank.KeyEncoding = (*Allocator)(nil)
+
+type AllocatorConfig struct {
+	// Bucket of allocated ranks. Defaults to lexorank.LexoRankBucket0.
+	Bucket *lexorank.LexoRankBucket
+	// Fail, if set, is asked before every Between with the zero-based number of
+	// the call; a non-nil error is returned instead of a rank.
+	Fail func(call int) error
+}
+
+// AllocatorCall records one Between call and its outcome.
+type AllocatorCall struct {
+	Prev string
+	Next string
+	Key  string
+	Err  error
+}
+
+// Allocator is a fake lexorank.KeyEncoding for code that takes one. It hands
+// out the ranks of lexorank.LexoRankEncoding, so the same calls always give the
+// same ranks, records every call, and can be told to fail. It is safe for
+// concurrent use.
+type Allocator struct {
+	config   AllocatorConfig
+	encoding *lexorank.LexoRankEncoding
+	mu       sync.Mutex
+	calls    []AllocatorCall
+}
+
+func NewAllocator(config AllocatorConfig) *Allocator {
+	if config.Bucket == nil {
+		config.Bucket = lexorank.LexoRankBucket0
+	}
+	return &Allocator{config: config, encoding: lexorank.NewLexoRankEncoding(config.Bucket)}
+}
+
+func (a *Allocator) Between(prev, next string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	call := AllocatorCall{Prev: prev, Next: next}
+	if a.config.Fail != nil {
+		call.Err = a.config.Fail(len(a.calls))
+	}
+	if call.Err == nil {
+		call.Key, call.Err = a.encoding.Between(prev, next)
+	}
+	a.calls = append(a.calls, call)
+	return call.Key, call.Err
+}
+
+func (a *Allocator) Validate(key string) error {
+	return a.encoding.Validate(key)
+}
+
+// Calls returns a copy of the calls made so far.
+func (a *Allocator) Calls() []AllocatorCall {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]AllocatorCall(nil), a.calls...)
+}
+
+// Keys returns the keys of n items appended one after another to an empty
+// list, the fixture a test would otherwise spell out by hand. It does not
+// record calls.
+func (a *Allocator) Keys(n int) []string {
+	keys := make([]string, n)
+	prev := ""
+	for idx := range keys {
+		key, err := a.encoding.Between(prev, "")
+		if err != nil {
+			panic("lexoranktest: " + err.Error())
+		}
+		keys[idx], prev = key, key
+	}
+	return keys
+}
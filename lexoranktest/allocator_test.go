@@ -0,0 +1,64 @@
+package lexoranktest
+
+import (
+	"errors"
+	"testing"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocator(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	alloc := NewAllocator(AllocatorConfig{
+		Bucket: lexorank.LexoRankBucket1,
+		Fail: func(call int) error {
+			if call == 2 {
+				return unavailable
+			}
+			return nil
+		},
+	})
+
+	first, err := alloc.Between("", "")
+	require.NoError(t, err)
+	second, err := alloc.Between(first, "")
+	require.NoError(t, err)
+	_, err = alloc.Between("", first)
+	assert.ErrorIs(t, err, unavailable)
+	_, err = alloc.Between("0|100000:", "")
+	assert.Error(t, err)
+
+	calls := alloc.Calls()
+	require.Len(t, calls, 4)
+	assert.Equal(t, []AllocatorCall{
+		{Key: first},
+		{Prev: first, Key: second},
+		{Next: first, Err: unavailable},
+	}, calls[:3])
+	assert.Equal(t, "0|100000:", calls[3].Prev)
+	assert.Error(t, calls[3].Err)
+	assert.NoError(t, alloc.Validate(second))
+	assert.Error(t, alloc.Validate("0|100000:"))
+
+	again := NewAllocator(AllocatorConfig{Bucket: lexorank.LexoRankBucket1})
+	keys := again.Keys(3)
+	assert.Equal(t, []string{first, second}, keys[:2])
+	assert.Empty(t, again.Calls())
+
+	ranks := make([]*lexorank.LexoRank, len(keys))
+	for idx, key := range keys {
+		AssertCanonical(t, key)
+		ranks[idx], _ = lexorank.LexoRankParse(key)
+	}
+	AssertStrictlyOrdered(t, ranks)
+}
+
+func TestAllocator_Bounds(t *testing.T) {
+	alloc := NewAllocator(AllocatorConfig{Bucket: lexorank.LexoRankBucket0})
+	_, err := alloc.Between("0|zzzzzz:", "")
+	assert.ErrorIs(t, err, lexorank.RankBoundErr)
+	_, err = alloc.Between("", "0|000000:")
+	assert.ErrorIs(t, err, lexorank.RankBoundErr)
+}
@@ -0,0 +1,58 @@
+// Package lexoranktest helps tests of code that stores LexoRanks: it generates
+// random ranks, checks order and canonical form, and fakes rank allocation, so
+// fixtures need not hard-code rank strings that break when the format changes.
+package lexoranktest
+
+import (
+	"testing"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+// AssertStrictlyOrdered reports an error for every nil rank and every pair of
+// neighbours that does not ascend strictly, both by Compare and as plain byte
+// strings, which is how databases order stored ranks. It returns whether ranks
+// passed.
+func AssertStrictlyOrdered(t testing.TB, ranks []*lexorank.LexoRank) bool {
+	t.Helper()
+	ok := true
+	for idx, rank := range ranks {
+		if rank == nil {
+			t.Errorf("rank %d is nil", idx)
+			ok = false
+			continue
+		}
+		if idx == 0 || ranks[idx-1] == nil {
+			continue
+		}
+		prev := ranks[idx-1]
+		if prev.Compare(rank) >= 0 {
+			t.Errorf("ranks %d and %d not strictly ascending: %s, %s", idx-1, idx, prev, rank)
+			ok = false
+		} else if prev.String() >= rank.String() {
+			t.Errorf("ranks %d and %d not strictly ascending as strings: %q, %q", idx-1, idx, prev, rank)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCanonical reports an error for every value that does not parse as a
+// rank or that differs from the string the library formats for it, such as
+// "0|hzzzzz:i0" for "0|hzzzzz:i". It returns whether all values passed.
+func AssertCanonical(t testing.TB, values ...string) bool {
+	t.Helper()
+	ok := true
+	for _, value := range values {
+		rank, err := lexorank.LexoRankParse(value)
+		switch {
+		case err != nil:
+			t.Errorf("rank %q does not parse: %v", value, err)
+			ok = false
+		case rank.String() != value:
+			t.Errorf("rank %q not canonical, expected %q", value, rank)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,64 @@
+package lexoranktest
+
+import (
+	"fmt"
+	"testing"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder collects the errors an assertion reports instead of failing the
+// test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertStrictlyOrdered(t *testing.T) {
+	rank := func(value string) *lexorank.LexoRank {
+		rank, err := lexorank.LexoRankParse(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rank
+	}
+	tests := []struct {
+		name   string
+		ranks  []*lexorank.LexoRank
+		errors int
+	}{
+		{name: "empty"},
+		{name: "ascending", ranks: []*lexorank.LexoRank{rank("0|100000:"), rank("0|100000:i"), rank("1|000000:")}},
+		{name: "equal", ranks: []*lexorank.LexoRank{rank("0|100000:"), rank("0|100000:")}, errors: 1},
+		{name: "descending", ranks: []*lexorank.LexoRank{rank("0|100001:"), rank("0|100000:"), rank("0|100002:")}, errors: 1},
+		{name: "nil", ranks: []*lexorank.LexoRank{rank("0|100000:"), nil, rank("0|100001:")}, errors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{TB: t}
+			assert.Equal(t, tt.errors == 0, AssertStrictlyOrdered(rec, tt.ranks))
+			assert.Len(t, rec.errors, tt.errors, rec.errors)
+		})
+	}
+}
+
+func TestAssertCanonical(t *testing.T) {
+	rec := &recorder{TB: t}
+	assert.True(t, AssertCanonical(rec, "0|hzzzzz:", "0|hzzzzz:i", "2|000000:"))
+	assert.Empty(t, rec.errors)
+
+	assert.False(t, AssertCanonical(rec, "0|hzzzzz:i0", "0|hzzzzz", "0|hzzzz:", "0|HZZZZZ:", "0|hzzzzz:"))
+	assert.Equal(t, []string{
+		`rank "0|hzzzzz:i0" not canonical, expected "0|hzzzzz:i"`,
+		`rank "0|hzzzzz" not canonical, expected "0|hzzzzz:"`,
+		`rank "0|hzzzz:" not canonical, expected "0|0hzzzz:"`,
+	}, rec.errors[:3])
+	assert.Len(t, rec.errors, 4)
+}
@@ -0,0 +1,54 @@
+package lexoranktest
+
+import (
+	"testing"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   GeneratorConfig
+		bucket   string
+		maxScale int
+	}{
+		{name: "defaults", bucket: "0", maxScale: DefaultMaxScale},
+		{name: "bucket", config: GeneratorConfig{Bucket: lexorank.LexoRankBucket2}, bucket: "2", maxScale: DefaultMaxScale},
+		{name: "scale", config: GeneratorConfig{MaxScale: 2, Seed: 7}, bucket: "0", maxScale: 2},
+		{name: "length", config: GeneratorConfig{MaxLength: 10}, bucket: "0", maxScale: 1},
+		{name: "integers only", config: GeneratorConfig{MaxLength: 9}, bucket: "0", maxScale: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			require.NoError(t, err)
+			ranks := gen.Ranks(200)
+			require.Len(t, ranks, 200)
+			AssertStrictlyOrdered(t, ranks)
+			for _, rank := range ranks {
+				AssertCanonical(t, rank.String())
+				assert.Equal(t, tt.bucket, rank.GetBucket().String())
+				assert.LessOrEqual(t, rank.GetScale(), tt.maxScale)
+				assert.False(t, rank.IsMin() || rank.IsMax())
+			}
+		})
+	}
+}
+
+func TestGenerator_Seed(t *testing.T) {
+	first, err := NewGenerator(GeneratorConfig{Seed: 42})
+	require.NoError(t, err)
+	second, err := NewGenerator(GeneratorConfig{Seed: 42})
+	require.NoError(t, err)
+	assert.Equal(t, first.Ranks(20), second.Ranks(20))
+}
+
+func TestNewGenerator_Invalid(t *testing.T) {
+	_, err := NewGenerator(GeneratorConfig{MaxScale: -1})
+	assert.Error(t, err)
+	_, err = NewGenerator(GeneratorConfig{MaxLength: 8})
+	assert.Error(t, err)
+}
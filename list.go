@@ -0,0 +1,415 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+var (
+	ItemExistsErr   = errors.New("item already in list")
+	ItemNotFoundErr = errors.New("item not in list")
+)
+
+type ListChangeKind int
+
+const (
+	// ListRankAssigned reports a new rank for one inserted or moved item.
+	ListRankAssigned ListChangeKind = iota
+	// ListRemoved reports an item removed from the list with its last rank.
+	ListRemoved
+	// ListRebalanced reports new ranks for a window of adjacent items, in list
+	// order.
+	ListRebalanced
+)
+
+// ListChange is emitted for every rank change an OrderedList makes, so callers
+// can persist the new ranks.
+type ListChange[T comparable] struct {
+	Kind  ListChangeKind
+	Items []RankedItem[T]
+}
+
+type OrderedListConfig[T comparable] struct {
+	// Bucket of assigned ranks. Defaults to LexoRankBucket0.
+	Bucket *LexoRankBucket
+	// MaxLength triggers a rebalance of the surrounding window when an assigned
+	// rank is longer, or when there is no room beyond a neighbour at the
+	// minimum or maximum rank. Zero disables rebalancing, and the latter fails
+	// with RankBoundErr.
+	MaxLength int
+	// Density is the share of a rebalanced window's capacity used. Defaults to
+	// 0.5.
+	Density float64
+	// OnChange receives every change. It is called before the method that made
+	// the change returns.
+	OnChange func(ListChange[T])
+}
+
+// OrderedList keeps items ordered by LexoRank. Items are identified by value,
+// so T is typically an ID or a pointer. Inserts, moves, removes and index
+// lookups take O(log n); a rebalance adds O(w log n) for a window of w items.
+// An OrderedList is not safe for concurrent use.
+type OrderedList[T comparable] struct {
+	config OrderedListConfig[T]
+	root   *listNode[T]
+	nodes  map[T]*listNode[T]
+	rnd    *rand.Rand
+}
+
+// listNode is a node of an implicit treap ordered by position; size counts the
+// nodes of the subtree for index lookups.
+type listNode[T comparable] struct {
+	item     T
+	rank     *LexoRank
+	priority uint32
+	size     int
+	left     *listNode[T]
+	right    *listNode[T]
+	parent   *listNode[T]
+}
+
+func NewOrderedList[T comparable](config OrderedListConfig[T]) (*OrderedList[T], error) {
+	if config.Bucket == nil {
+		config.Bucket = LexoRankBucket0
+	}
+	if config.Density == 0 {
+		config.Density = 0.5
+	}
+	if config.Density < 0 || config.Density > 1 {
+		return nil, fmt.Errorf("density out of range (0, 1]: %v", config.Density)
+	}
+	if config.MaxLength < 0 || config.MaxLength > 0 && maxScale(config.Bucket, config.MaxLength) < 0 {
+		return nil, fmt.Errorf("max length too small: %d", config.MaxLength)
+	}
+	return &OrderedList[T]{
+		config: config,
+		nodes:  make(map[T]*listNode[T]),
+		rnd:    rand.New(rand.NewSource(1)),
+	}, nil
+}
+
+func (l *OrderedList[T]) Len() int {
+	return l.root.count()
+}
+
+// Add inserts item with an existing rank, for loading a list from storage. No
+// change is emitted.
+func (l *OrderedList[T]) Add(item T, rank *LexoRank) error {
+	if _, ok := l.nodes[item]; ok {
+		return fmt.Errorf("%w: %v", ItemExistsErr, item)
+	}
+	if rank == nil {
+		return NilRankErr
+	}
+	if !rank.bucket.Equals(l.config.Bucket) {
+		return RanksMixedBucketErr
+	}
+	index := 0
+	for node := l.root; node != nil; {
+		if cmp := node.rank.Compare(rank); cmp == 0 {
+			return fmt.Errorf("%w: %s taken by %v", RanksNotSortedErr, rank, node.item)
+		} else if cmp < 0 {
+			index += node.left.count() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	l.insert(index, item, rank)
+	return nil
+}
+
+func (l *OrderedList[T]) PushFront(item T) (*LexoRank, error) {
+	return l.insertAt(0, item)
+}
+
+func (l *OrderedList[T]) PushBack(item T) (*LexoRank, error) {
+	return l.insertAt(l.Len(), item)
+}
+
+func (l *OrderedList[T]) InsertBefore(item, mark T) (*LexoRank, error) {
+	index, ok := l.IndexOf(mark)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ItemNotFoundErr, mark)
+	}
+	return l.insertAt(index, item)
+}
+
+func (l *OrderedList[T]) InsertAfter(item, mark T) (*LexoRank, error) {
+	index, ok := l.IndexOf(mark)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ItemNotFoundErr, mark)
+	}
+	return l.insertAt(index+1, item)
+}
+
+// Move moves item to index, counted after item has been taken out of the list.
+func (l *OrderedList[T]) Move(item T, index int) (*LexoRank, error) {
+	node, ok := l.nodes[item]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ItemNotFoundErr, item)
+	}
+	if index < 0 || index >= l.Len() {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", index, l.Len())
+	}
+	if node.index() == index {
+		return node.rank, nil
+	}
+	return l.reinsert(node, func() int { return index })
+}
+
+func (l *OrderedList[T]) MoveBefore(item, mark T) (*LexoRank, error) {
+	return l.moveNextTo(item, mark, 0)
+}
+
+func (l *OrderedList[T]) MoveAfter(item, mark T) (*LexoRank, error) {
+	return l.moveNextTo(item, mark, 1)
+}
+
+func (l *OrderedList[T]) moveNextTo(item, mark T, offset int) (*LexoRank, error) {
+	node, ok := l.nodes[item]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ItemNotFoundErr, item)
+	}
+	if _, ok := l.nodes[mark]; !ok {
+		return nil, fmt.Errorf("%w: %v", ItemNotFoundErr, mark)
+	}
+	if item == mark {
+		return node.rank, nil
+	}
+	return l.reinsert(node, func() int {
+		index, _ := l.IndexOf(mark)
+		return index + offset
+	})
+}
+
+// reinsert takes node out of the list and inserts its item at the index
+// returned by target, putting it back where it was if that fails.
+func (l *OrderedList[T]) reinsert(node *listNode[T], target func() int) (*LexoRank, error) {
+	from := node.index()
+	l.remove(node)
+	rank, err := l.insertAt(target(), node.item)
+	if err != nil {
+		l.insert(from, node.item, node.rank)
+		return nil, err
+	}
+	return rank, nil
+}
+
+// Remove takes item out of the list and emits ListRemoved.
+func (l *OrderedList[T]) Remove(item T) error {
+	node, ok := l.nodes[item]
+	if !ok {
+		return fmt.Errorf("%w: %v", ItemNotFoundErr, item)
+	}
+	l.remove(node)
+	l.emit(ListChange[T]{Kind: ListRemoved, Items: []RankedItem[T]{{ID: item, Rank: node.rank}}})
+	return nil
+}
+
+func (l *OrderedList[T]) Rank(item T) (*LexoRank, bool) {
+	node, ok := l.nodes[item]
+	if !ok {
+		return nil, false
+	}
+	return node.rank, true
+}
+
+func (l *OrderedList[T]) IndexOf(item T) (int, bool) {
+	node, ok := l.nodes[item]
+	if !ok {
+		return -1, false
+	}
+	return node.index(), true
+}
+
+// At returns the item at index and its rank.
+func (l *OrderedList[T]) At(index int) (T, *LexoRank, bool) {
+	node := l.root.at(index)
+	if node == nil {
+		var zero T
+		return zero, nil, false
+	}
+	return node.item, node.rank, true
+}
+
+// Each calls fn for every item in order until fn returns false.
+func (l *OrderedList[T]) Each(fn func(index int, item T, rank *LexoRank) bool) {
+	l.root.each(0, fn)
+}
+
+func (l *OrderedList[T]) insertAt(index int, item T) (*LexoRank, error) {
+	if _, ok := l.nodes[item]; ok {
+		return nil, fmt.Errorf("%w: %v", ItemExistsErr, item)
+	}
+	var prev, next *LexoRank
+	if node := l.root.at(index - 1); node != nil {
+		prev = node.rank
+	}
+	if node := l.root.at(index); node != nil {
+		next = node.rank
+	}
+	rank, err := rankBetween(l.config.Bucket, prev, next)
+	rebalance := l.config.MaxLength > 0 && (errors.Is(err, RankBoundErr) || err == nil && len(rank.String()) > l.config.MaxLength)
+	var window RebalanceWindow
+	if rebalance {
+		window, err = l.window(index, rank)
+	}
+	if err != nil {
+		return nil, err
+	}
+	node := l.insert(index, item, rank)
+	if rebalance {
+		l.apply(window)
+		return node.rank, nil
+	}
+	l.emit(ListChange[T]{Kind: ListRankAssigned, Items: []RankedItem[T]{{ID: item, Rank: rank}}})
+	return rank, nil
+}
+
+// window finds the smallest window that fits MaxLength around a new item at
+// index with rank, which is nil when there was no room for one, before the item
+// is inserted, so that a failure leaves the list unchanged.
+func (l *OrderedList[T]) window(index int, rank *LexoRank) (RebalanceWindow, error) {
+	at := func(idx int) *LexoRank {
+		switch {
+		case idx < index:
+			return l.root.at(idx).rank
+		case idx == index:
+			return rank
+		}
+		return l.root.at(idx - 1).rank
+	}
+	scale := maxScale(l.config.Bucket, l.config.MaxLength)
+	return expandWindow(at, l.Len()+1, l.config.Bucket, index, index+1, scale, l.config.Density)
+}
+
+// apply gives the items of window their new ranks and emits them as one
+// ListRebalanced change.
+func (l *OrderedList[T]) apply(window RebalanceWindow) {
+	change := ListChange[T]{Kind: ListRebalanced, Items: make([]RankedItem[T], len(window.Ranks))}
+	for idx, rank := range window.Ranks {
+		node := l.root.at(window.Start + idx)
+		node.rank = rank
+		change.Items[idx] = RankedItem[T]{ID: node.item, Rank: rank}
+	}
+	l.emit(change)
+}
+
+func (l *OrderedList[T]) emit(change ListChange[T]) {
+	if l.config.OnChange != nil {
+		l.config.OnChange(change)
+	}
+}
+
+func (l *OrderedList[T]) insert(index int, item T, rank *LexoRank) *listNode[T] {
+	node := &listNode[T]{item: item, rank: rank, priority: l.rnd.Uint32(), size: 1}
+	left, right := split(l.root, index)
+	l.root = merge(merge(left, node), right)
+	l.root.parent = nil
+	l.nodes[item] = node
+	return node
+}
+
+func (l *OrderedList[T]) remove(node *listNode[T]) {
+	left, rest := split(l.root, node.index())
+	_, right := split(rest, 1)
+	l.root = merge(left, right)
+	if l.root != nil {
+		l.root.parent = nil
+	}
+	delete(l.nodes, node.item)
+}
+
+func (n *listNode[T]) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *listNode[T]) update() {
+	n.size = n.left.count() + n.right.count() + 1
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+}
+
+func (n *listNode[T]) index() int {
+	index := n.left.count()
+	for node := n; node.parent != nil; node = node.parent {
+		if node == node.parent.right {
+			index += node.parent.left.count() + 1
+		}
+	}
+	return index
+}
+
+func (n *listNode[T]) at(index int) *listNode[T] {
+	for node := n; node != nil; {
+		switch left := node.left.count(); {
+		case index < left:
+			node = node.left
+		case index == left:
+			return node
+		default:
+			index -= left + 1
+			node = node.right
+		}
+	}
+	return nil
+}
+
+func (n *listNode[T]) each(offset int, fn func(index int, item T, rank *LexoRank) bool) (int, bool) {
+	if n == nil {
+		return offset, true
+	}
+	offset, ok := n.left.each(offset, fn)
+	if !ok || !fn(offset, n.item, n.rank) {
+		return offset, false
+	}
+	return n.right.each(offset+1, fn)
+}
+
+// split cuts the treap into its first index nodes and the rest.
+func split[T comparable](n *listNode[T], index int) (*listNode[T], *listNode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.left.count() >= index {
+		left, right := split(n.left, index)
+		n.left = right
+		n.update()
+		if left != nil {
+			left.parent = nil
+		}
+		return left, n
+	}
+	left, right := split(n.right, index-n.left.count()-1)
+	n.right = left
+	n.update()
+	if right != nil {
+		right.parent = nil
+	}
+	return n, right
+}
+
+func merge[T comparable](left, right *listNode[T]) *listNode[T] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.right = merge(left.right, right)
+		left.update()
+		return left
+	}
+	right.left = merge(left, right.left)
+	right.update()
+	return right
+}
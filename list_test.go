@@ -0,0 +1,218 @@
+package lexorank
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listItems(l *OrderedList[int]) []int {
+	var items []int
+	l.Each(func(index int, item int, rank *LexoRank) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+func TestOrderedList(t *testing.T) {
+	var changes []ListChange[string]
+	list, err := NewOrderedList(OrderedListConfig[string]{
+		OnChange: func(change ListChange[string]) { changes = append(changes, change) },
+	})
+	require.NoError(t, err)
+	rank, err := list.PushBack("a")
+	require.NoError(t, err)
+	assert.Equal(t, "0|hzzzzz:", rank.String())
+	rank, err = list.PushBack("b")
+	require.NoError(t, err)
+	assert.Equal(t, "0|i00007:", rank.String())
+	rank, err = list.InsertBefore("c", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "0|i00003:", rank.String())
+	rank, err = list.PushFront("d")
+	require.NoError(t, err)
+	assert.Equal(t, "0|hzzzzr:", rank.String())
+
+	var order []string
+	list.Each(func(index int, item string, rank *LexoRank) bool {
+		order = append(order, item)
+		return true
+	})
+	assert.Equal(t, []string{"d", "a", "c", "b"}, order)
+
+	rank, err = list.MoveAfter("d", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "0|i0000f:", rank.String())
+	index, ok := list.IndexOf("d")
+	assert.True(t, ok)
+	assert.Equal(t, 3, index)
+	item, _, ok := list.At(0)
+	assert.True(t, ok)
+	assert.Equal(t, "a", item)
+
+	require.NoError(t, list.Remove("c"))
+	assert.Equal(t, 3, list.Len())
+	_, ok = list.IndexOf("c")
+	assert.False(t, ok)
+
+	assert.ErrorIs(t, list.Remove("c"), ItemNotFoundErr)
+	_, err = list.PushBack("a")
+	assert.ErrorIs(t, err, ItemExistsErr)
+	_, err = list.InsertAfter("e", "c")
+	assert.ErrorIs(t, err, ItemNotFoundErr)
+
+	require.Len(t, changes, 6)
+	assert.Equal(t, ListRankAssigned, changes[4].Kind)
+	assert.Equal(t, []RankedItem[string]{{ID: "d", Rank: rank}}, changes[4].Items)
+	assert.Equal(t, ListRemoved, changes[5].Kind)
+	assert.Equal(t, "c", changes[5].Items[0].ID)
+}
+
+func TestOrderedList_Add(t *testing.T) {
+	list, err := NewOrderedList(OrderedListConfig[int]{})
+	require.NoError(t, err)
+	for idx, rank := range parseRanks(t, "0|100002:", "0|100000:", "0|100001:i", "0|100001:") {
+		require.NoError(t, list.Add(idx, rank))
+	}
+	assert.Equal(t, []int{1, 3, 2, 0}, listItems(list))
+
+	taken := parseRanks(t, "0|100001:")[0]
+	assert.ErrorIs(t, list.Add(9, taken), RanksNotSortedErr)
+	assert.ErrorIs(t, list.Add(9, taken.InBucket(LexoRankBucket1)), RanksMixedBucketErr)
+}
+
+func TestOrderedList_Random(t *testing.T) {
+	persisted := map[int]*LexoRank{}
+	rebalances := 0
+	list, err := NewOrderedList(OrderedListConfig[int]{
+		MaxLength: 12,
+		OnChange: func(change ListChange[int]) {
+			if change.Kind == ListRebalanced {
+				rebalances++
+			}
+			for _, item := range change.Items {
+				if change.Kind == ListRemoved {
+					delete(persisted, item.ID)
+				} else {
+					persisted[item.ID] = item.Rank
+				}
+			}
+		},
+	})
+	require.NoError(t, err)
+	var model []int
+	rnd := rand.New(rand.NewSource(1))
+	for step := 0; step < 3000; step++ {
+		switch op := rnd.Intn(10); {
+		case op < 5 || len(model) < 2:
+			index := rnd.Intn(len(model) + 1)
+			if index > 0 && rnd.Intn(4) > 0 {
+				index = len(model) / 3
+			}
+			if index == len(model) {
+				_, err := list.PushBack(step)
+				require.NoError(t, err)
+			} else {
+				_, err := list.InsertBefore(step, model[index])
+				require.NoError(t, err)
+			}
+			model = append(model[:index], append([]int{step}, model[index:]...)...)
+		case op < 8:
+			from, to := rnd.Intn(len(model)), rnd.Intn(len(model))
+			item := model[from]
+			_, err := list.Move(item, to)
+			require.NoError(t, err)
+			model = append(model[:from], model[from+1:]...)
+			model = append(model[:to], append([]int{item}, model[to:]...)...)
+		default:
+			index := rnd.Intn(len(model))
+			require.NoError(t, list.Remove(model[index]))
+			model = append(model[:index], model[index+1:]...)
+		}
+	}
+
+	assert.Equal(t, model, listItems(list))
+	assert.Len(t, persisted, len(model))
+	assert.Greater(t, rebalances, 0)
+	var prev *LexoRank
+	for idx, item := range model {
+		index, ok := list.IndexOf(item)
+		assert.True(t, ok)
+		assert.Equal(t, idx, index)
+		got, rank, _ := list.At(idx)
+		assert.Equal(t, item, got)
+		assert.Equal(t, persisted[item], rank)
+		assert.LessOrEqual(t, rank.GetLength(), 12)
+		if prev != nil {
+			assert.Equal(t, -1, prev.Compare(rank))
+		}
+		prev = rank
+	}
+}
+
+func TestNewOrderedList_Invalid(t *testing.T) {
+	_, err := NewOrderedList(OrderedListConfig[int]{MaxLength: 5})
+	assert.Error(t, err)
+	_, err = NewOrderedList(OrderedListConfig[int]{MaxLength: -1})
+	assert.Error(t, err)
+	_, err = NewOrderedList(OrderedListConfig[int]{Density: 2})
+	assert.Error(t, err)
+}
+
+func TestOrderedList_Bounds(t *testing.T) {
+	var changes []ListChange[string]
+	list, err := NewOrderedList(OrderedListConfig[string]{
+		OnChange: func(change ListChange[string]) { changes = append(changes, change) },
+	})
+	require.NoError(t, err)
+	require.NoError(t, list.Add("max", MaxLexoRank))
+	require.NoError(t, list.Add("min", MinLexoRank))
+
+	_, err = list.PushBack("n")
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = list.PushFront("n")
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = list.MoveBefore("max", "min")
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = list.Move("min", 1)
+	assert.ErrorIs(t, err, RankBoundErr)
+	assert.Equal(t, 2, list.Len())
+	rank, ok := list.Rank("min")
+	assert.True(t, ok)
+	assert.Equal(t, MinLexoRank, rank)
+	index, _ := list.IndexOf("max")
+	assert.Equal(t, 1, index)
+	assert.Empty(t, changes)
+
+	rank, err = list.InsertAfter("n", "min")
+	require.NoError(t, err)
+	assert.Equal(t, "0|hzzzzz:", rank.String())
+}
+
+func TestOrderedList_BoundsRebalance(t *testing.T) {
+	var changes []ListChange[string]
+	list, err := NewOrderedList(OrderedListConfig[string]{
+		MaxLength: 10,
+		OnChange:  func(change ListChange[string]) { changes = append(changes, change) },
+	})
+	require.NoError(t, err)
+	require.NoError(t, list.Add("min", MinLexoRank))
+	require.NoError(t, list.Add("max", MaxLexoRank))
+
+	_, err = list.PushBack("n")
+	require.NoError(t, err)
+	_, err = list.PushFront("p")
+	require.NoError(t, err)
+	var ranks []*LexoRank
+	list.Each(func(index int, item string, rank *LexoRank) bool {
+		ranks = append(ranks, rank)
+		return true
+	})
+	assert.Equal(t, parseRanks(t, "0|3zzzzz:", "0|7zzzzz:", "0|bzzzzz:", "0|nzzzzz:"), ranks)
+	require.Len(t, changes, 2)
+	assert.Equal(t, ListRebalanced, changes[0].Kind)
+	assert.Equal(t, ListRebalanced, changes[1].Kind)
+}
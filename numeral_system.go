@@ -6,6 +6,7 @@ import (
 
 const (
 	map36 = "0123456789abcdefghijklmnopqrstuvwxyz"
+	map62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	map64 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ^_abcdefghijklmnopqrstuvwxyz"
 )
 
@@ -21,6 +22,7 @@ type LexoNumeralSystem interface {
 var (
 	_ LexoNumeralSystem = (*LexoNumeralSystem10)(nil)
 	_ LexoNumeralSystem = (*LexoNumeralSystem36)(nil)
+	_ LexoNumeralSystem = (*LexoNumeralSystem62)(nil)
 	_ LexoNumeralSystem = (*LexoNumeralSystem64)(nil)
 )
 
@@ -98,6 +100,48 @@ func (n *LexoNumeralSystem36) Char(digit byte) byte {
 	return map36[digit]
 }
 
+type LexoNumeralSystem62 struct {
+}
+
+func NewLexoNumeralSystem62() *LexoNumeralSystem62 {
+	return &LexoNumeralSystem62{}
+}
+
+func (n *LexoNumeralSystem62) GetBase() byte {
+	return 62
+}
+
+func (n *LexoNumeralSystem62) GetPositiveChar() byte {
+	return '+'
+}
+
+func (n *LexoNumeralSystem62) GetNegativeChar() byte {
+	return '-'
+}
+
+func (n *LexoNumeralSystem62) GetRadixPointChar() byte {
+	return ':'
+}
+
+func (n *LexoNumeralSystem62) Digit(ch byte) (byte, error) {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return ch - '0', nil
+
+	case ch >= 'A' && ch <= 'Z':
+		return ch - 'A' + 10, nil
+
+	case ch >= 'a' && ch <= 'z':
+		return ch - 'a' + 36, nil
+	}
+
+	return 0, fmt.Errorf("not valid digit: %q", ch)
+}
+
+func (n *LexoNumeralSystem62) Char(digit byte) byte {
+	return map62[digit]
+}
+
 type LexoNumeralSystem64 struct {
 }
 
@@ -0,0 +1,106 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// floatScale is the scale of ranks made from float64 values: 6+5 base-36 digits
+// resolve more than the 53 bits of a float64 mantissa.
+const floatScale = 5
+
+// LexoRankFromInt64 maps value in [min, max] to a rank strictly between the
+// bucket minimum and maximum. Distinct values get distinct ranks, spaced evenly
+// over the whole bucket, and larger values get larger ranks.
+func LexoRankFromInt64(bucket *LexoRankBucket, value, min, max int64) (*LexoRank, error) {
+	if min > max || value < min || value > max {
+		return nil, fmt.Errorf("value %d outside [%d, %d]", value, min, max)
+	}
+	return lexoRankFromIndex(bucket, new(big.Int).Sub(big.NewInt(value), big.NewInt(min)), new(big.Int).Sub(big.NewInt(max), big.NewInt(min))), nil
+}
+
+// LexoRankFromUint64 is LexoRankFromInt64 for unsigned values.
+func LexoRankFromUint64(bucket *LexoRankBucket, value, min, max uint64) (*LexoRank, error) {
+	if min > max || value < min || value > max {
+		return nil, fmt.Errorf("value %d outside [%d, %d]", value, min, max)
+	}
+	return lexoRankFromIndex(bucket, new(big.Int).SetUint64(value-min), new(big.Int).SetUint64(max-min)), nil
+}
+
+// LexoRankFromFloat64 maps value in [min, max] to a rank strictly between the
+// bucket minimum and maximum. The mapping never reverses order, but values
+// closer together than the rank resolution share a rank.
+func LexoRankFromFloat64(bucket *LexoRankBucket, value, min, max float64) (*LexoRank, error) {
+	ratValue, ratMin, ratMax := new(big.Rat).SetFloat64(value), new(big.Rat).SetFloat64(min), new(big.Rat).SetFloat64(max)
+	if ratValue == nil || ratMin == nil || ratMax == nil {
+		return nil, errors.New("value and range must be finite")
+	}
+	return LexoRankFromRat(bucket, ratValue, ratMin, ratMax, floatScale)
+}
+
+// LexoRankFromRat maps value in [min, max] to a rank strictly between the bucket
+// minimum and maximum, rounded down to scale fraction digits. The mapping never
+// reverses order; values closer together than the resolution at scale share a
+// rank, and min and max land one step inside the bucket bounds. As with
+// LexoRankFromInt64, a range of one value maps it to the middle of the bucket.
+func LexoRankFromRat(bucket *LexoRankBucket, value, min, max *big.Rat, scale int) (*LexoRank, error) {
+	if min.Cmp(max) > 0 || value.Cmp(min) < 0 || value.Cmp(max) > 0 {
+		return nil, fmt.Errorf("value %s outside [%s, %s]", value.RatString(), min.RatString(), max.RatString())
+	}
+	if scale < 0 {
+		return nil, fmt.Errorf("negative scale: %d", scale)
+	}
+	if min.Cmp(max) == 0 {
+		return lexoRankFromIndex(bucket, new(big.Int), new(big.Int)), nil
+	}
+	fraction := new(big.Rat).Sub(value, min)
+	fraction.Quo(fraction, new(big.Rat).Sub(max, min))
+
+	span := rankSpan(scale)
+	pos := new(big.Int).Mul(span, fraction.Num())
+	pos.Quo(pos, fraction.Denom())
+	switch {
+	case pos.Sign() == 0:
+		pos.SetInt64(1)
+	case pos.Cmp(span) == 0:
+		pos.Sub(pos, big.NewInt(1))
+	}
+	return lexoRankAt(bucket, pos, scale), nil
+}
+
+// Fraction returns how far the rank lies between the bucket minimum (0) and
+// maximum (1), for position indicators and the like.
+func (i *LexoRank) Fraction() *big.Rat {
+	if i == nil {
+		return nil
+	}
+	scale := i.decimal.scale
+	pos := i.decimal.Sub(minDecimal).floorAt(scale).bigInt()
+	return new(big.Rat).SetFrac(pos, rankSpan(scale))
+}
+
+// lexoRankFromIndex places index in [0, last] at (index+1)/(last+2) of the
+// bucket, at the smallest scale where all last+1 indexes get distinct ranks.
+func lexoRankFromIndex(bucket *LexoRankBucket, index, last *big.Int) *LexoRank {
+	slots := new(big.Int).Add(last, big.NewInt(2))
+	scale := 0
+	for rankSpan(scale).Cmp(slots) < 0 {
+		scale++
+	}
+	pos := rankSpan(scale)
+	pos.Mul(pos, new(big.Int).Add(index, big.NewInt(1)))
+	pos.Quo(pos, slots)
+	return lexoRankAt(bucket, pos, scale)
+}
+
+// rankSpan is the distance between the bucket minimum and maximum in units of
+// the last digit at scale.
+func rankSpan(scale int) *big.Int {
+	return maxDecimal.Sub(minDecimal).floorAt(scale).bigInt()
+}
+
+func lexoRankAt(bucket *LexoRankBucket, pos *big.Int, scale int) *LexoRank {
+	offset := LexoDecimalMake(lexoIntegerFromBig(LexoRankSystem, pos), scale)
+	return NewLexoRank(bucket, minDecimal.Add(offset))
+}
@@ -0,0 +1,105 @@
+package lexorank
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexoRankFromInt64(t *testing.T) {
+	tests := []struct {
+		value   int64
+		min     int64
+		max     int64
+		want    string
+		wantErr bool
+	}{
+		{value: 0, min: 0, max: 0, want: "0|hzzzzz:"},
+		{value: 0, min: 0, max: 2, want: "0|8zzzzz:"},
+		{value: 1, min: 0, max: 2, want: "0|hzzzzz:"},
+		{value: 2, min: 0, max: 2, want: "0|qzzzzz:"},
+		{value: math.MinInt64, min: math.MinInt64, max: math.MaxInt64, want: "0|000000:0000009"},
+		{value: math.MaxInt64, min: math.MinInt64, max: math.MaxInt64, want: "0|zzzzzy:zzzzzzq"},
+		{value: 3, min: 0, max: 2, wantErr: true},
+		{value: 1, min: 2, max: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := LexoRankFromInt64(LexoRankBucket0, tt.value, tt.min, tt.max)
+		if tt.wantErr {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equalf(t, tt.want, got.String(), "LexoRankFromInt64(%d, %d, %d)", tt.value, tt.min, tt.max)
+	}
+}
+
+func TestLexoRankFromInt64_Order(t *testing.T) {
+	prev := MinLexoRank
+	for value := int64(-1000); value <= 1000; value++ {
+		rank, err := LexoRankFromInt64(LexoRankBucket0, value, -1000, 1000)
+		assert.NoError(t, err)
+		assert.Less(t, prev.String(), rank.String())
+		prev = rank
+	}
+	assert.Less(t, prev.String(), MaxLexoRank.String())
+
+	low, _ := LexoRankFromUint64(LexoRankBucket0, math.MaxUint64-1, 0, math.MaxUint64)
+	high, _ := LexoRankFromUint64(LexoRankBucket0, math.MaxUint64, 0, math.MaxUint64)
+	assert.Less(t, low.String(), high.String())
+	assert.Less(t, high.String(), MaxLexoRank.String())
+}
+
+func TestLexoRankFromFloat64(t *testing.T) {
+	values := []float64{-1, -0.5, -1e-9, 0, 1e-9, 0.25, 0.999999, 1}
+	prev := MinLexoRank
+	for _, value := range values {
+		rank, err := LexoRankFromFloat64(LexoRankBucket0, value, -1, 1)
+		assert.NoError(t, err)
+		assert.Less(t, prev.String(), rank.String())
+		prev = rank
+	}
+	assert.Less(t, prev.String(), MaxLexoRank.String())
+
+	_, err := LexoRankFromFloat64(LexoRankBucket0, math.NaN(), -1, 1)
+	assert.Error(t, err)
+	_, err = LexoRankFromFloat64(LexoRankBucket0, 0, 1, 1)
+	assert.Error(t, err)
+	_, err = LexoRankFromFloat64(LexoRankBucket0, 2, -1, 1)
+	assert.Error(t, err)
+}
+
+func TestLexoRank_Fraction(t *testing.T) {
+	assert.Equal(t, "0", MinLexoRank.Fraction().RatString())
+	assert.Equal(t, "1", MaxLexoRank.Fraction().RatString())
+	mid, _ := MidLexoRank.Fraction().Float64()
+	assert.InDelta(t, 0.5, mid, 1e-9)
+
+	rank, err := LexoRankFromRat(LexoRankBucket0, big.NewRat(1, 4), big.NewRat(0, 1), big.NewRat(1, 1), 3)
+	assert.NoError(t, err)
+	quarter, _ := rank.Fraction().Float64()
+	assert.InDelta(t, 0.25, quarter, 1e-9)
+}
+
+func TestLexoRankFrom_SingleValueRange(t *testing.T) {
+	fromInt, err := LexoRankFromInt64(LexoRankBucket1, 7, 7, 7)
+	assert.NoError(t, err)
+	fromUint, err := LexoRankFromUint64(LexoRankBucket1, 7, 7, 7)
+	assert.NoError(t, err)
+	fromFloat, err := LexoRankFromFloat64(LexoRankBucket1, 7, 7, 7)
+	assert.NoError(t, err)
+	fromRat, err := LexoRankFromRat(LexoRankBucket1, big.NewRat(7, 1), big.NewRat(7, 1), big.NewRat(7, 1), 3)
+	assert.NoError(t, err)
+	for _, rank := range []*LexoRank{fromInt, fromUint, fromFloat, fromRat} {
+		assert.Equal(t, "1|hzzzzz:", rank.String())
+	}
+
+	_, err = LexoRankFromUint64(LexoRankBucket1, 6, 7, 7)
+	assert.Error(t, err)
+	_, err = LexoRankFromFloat64(LexoRankBucket1, 8, 7, 7)
+	assert.Error(t, err)
+	_, err = LexoRankFromFloat64(LexoRankBucket1, 7, 8, 6)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LexoRankRange is the half-open range [Lower, Upper) of ranks in one bucket.
+type LexoRankRange struct {
+	Lower *LexoRank
+	Upper *LexoRank
+}
+
+func NewLexoRankRange(lower, upper *LexoRank) (LexoRankRange, error) {
+	if lower == nil || upper == nil {
+		return LexoRankRange{}, NilRankErr
+	}
+	if !lower.bucket.Equals(upper.bucket) {
+		return LexoRankRange{}, errors.New("range works only within the same bucket")
+	}
+	if lower.Compare(upper) > 0 {
+		return LexoRankRange{}, fmt.Errorf("lower %s above upper %s", lower, upper)
+	}
+	return LexoRankRange{Lower: lower, Upper: upper}, nil
+}
+
+// FullLexoRankRange covers the whole bucket, from MinLexoRank up to but not
+// including MaxLexoRank.
+func FullLexoRankRange(bucket *LexoRankBucket) LexoRankRange {
+	return LexoRankRange{Lower: minLexoRank(bucket), Upper: maxLexoRank(bucket)}
+}
+
+func (r LexoRankRange) String() string {
+	return "[" + r.Lower.String() + ", " + r.Upper.String() + ")"
+}
+
+func (r LexoRankRange) IsEmpty() bool {
+	return r.Lower.Compare(r.Upper) >= 0
+}
+
+func (r LexoRankRange) Contains(rank *LexoRank) bool {
+	return r.Lower.Compare(rank) <= 0 && rank.Compare(r.Upper) < 0
+}
+
+// Intersect returns the overlap of two ranges, which is empty when they do not
+// overlap.
+func (r LexoRankRange) Intersect(other LexoRankRange) LexoRankRange {
+	result := r
+	if other.Lower.Compare(result.Lower) > 0 {
+		result.Lower = other.Lower
+	}
+	if other.Upper.Compare(result.Upper) < 0 {
+		result.Upper = other.Upper
+	}
+	if result.IsEmpty() {
+		return LexoRankRange{Lower: result.Lower, Upper: result.Lower}
+	}
+	return result
+}
+
+// Mid returns a rank strictly between Lower and Upper.
+func (r LexoRankRange) Mid() (*LexoRank, error) {
+	if r.IsEmpty() {
+		return nil, fmt.Errorf("empty range %s", r)
+	}
+	return r.Lower.Between(r.Upper)
+}
+
+// Split divides the range into n contiguous sub-ranges of about equal width.
+// The first starts at Lower, the last ends at Upper, and each ends where the
+// next starts.
+func (r LexoRankRange) Split(n int) ([]LexoRankRange, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("non-positive count: %d", n)
+	}
+	if r.IsEmpty() {
+		return nil, fmt.Errorf("empty range %s", r)
+	}
+	bounds, err := r.Lower.BetweenN(r.Upper, n-1)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]LexoRankRange, n)
+	lower := r.Lower
+	for idx, upper := range append(bounds, r.Upper) {
+		ranges[idx] = LexoRankRange{Lower: lower, Upper: upper}
+		lower = upper
+	}
+	return ranges, nil
+}
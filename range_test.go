@@ -0,0 +1,72 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRange(t *testing.T, lower, upper string) LexoRankRange {
+	bounds := parseRanks(t, lower, upper)
+	r, err := NewLexoRankRange(bounds[0], bounds[1])
+	require.NoError(t, err)
+	return r
+}
+
+func TestLexoRankRange_Contains(t *testing.T) {
+	r := mustRange(t, "0|100000:", "0|200000:")
+	for value, want := range map[string]bool{
+		"0|0zzzzz:":  false,
+		"0|100000:":  true,
+		"0|1zzzzz:z": true,
+		"0|200000:":  false,
+		"1|150000:":  false,
+	} {
+		assert.Equalf(t, want, r.Contains(parseRanks(t, value)[0]), "Contains(%s)", value)
+	}
+}
+
+func TestLexoRankRange_Intersect(t *testing.T) {
+	r := mustRange(t, "0|100000:", "0|200000:")
+	assert.Equal(t, "[0|180000:, 0|200000:)", r.Intersect(mustRange(t, "0|180000:", "0|300000:")).String())
+	assert.Equal(t, "[0|120000:, 0|130000:)", r.Intersect(mustRange(t, "0|120000:", "0|130000:")).String())
+	assert.True(t, r.Intersect(mustRange(t, "0|200000:", "0|300000:")).IsEmpty())
+	assert.True(t, r.Intersect(mustRange(t, "0|300000:", "0|400000:")).IsEmpty())
+}
+
+func TestLexoRankRange_Mid(t *testing.T) {
+	mid, err := mustRange(t, "0|100000:", "0|100001:").Mid()
+	require.NoError(t, err)
+	assert.Equal(t, "0|100000:i", mid.String())
+	_, err = mustRange(t, "0|100000:", "0|100000:").Mid()
+	assert.Error(t, err)
+}
+
+func TestLexoRankRange_Split(t *testing.T) {
+	full := FullLexoRankRange(LexoRankBucket0)
+	parts, err := full.Split(3)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "[0|000000:, 0|bzzzzz:)", parts[0].String())
+	assert.Equal(t, "[0|bzzzzz:, 0|nzzzzz:)", parts[1].String())
+	assert.Equal(t, "[0|nzzzzz:, 0|zzzzzz:)", parts[2].String())
+
+	narrow := mustRange(t, "0|100000:", "0|100001:")
+	parts, err = narrow.Split(4)
+	require.NoError(t, err)
+	assert.Equal(t, narrow.Lower, parts[0].Lower)
+	assert.Equal(t, narrow.Upper, parts[3].Upper)
+	for idx, part := range parts {
+		assert.False(t, part.IsEmpty())
+		if idx > 0 {
+			assert.Equal(t, parts[idx-1].Upper, part.Lower)
+		}
+	}
+
+	_, err = narrow.Split(0)
+	assert.Error(t, err)
+	parts, err = narrow.Split(1)
+	require.NoError(t, err)
+	assert.Equal(t, []LexoRankRange{narrow}, parts)
+}
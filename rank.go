@@ -0,0 +1,112 @@
+package lexorank
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+)
+
+var (
+	_ encoding.TextMarshaler   = Rank{}
+	_ encoding.TextUnmarshaler = (*Rank)(nil)
+)
+
+// Rank is an immutable, comparable LexoRank value. Equal ranks are ==, so Rank
+// works as a map key, and the zero Rank means "unset". A Rank holds only its
+// canonical string and can be copied and shared between goroutines freely.
+type Rank struct {
+	value string
+}
+
+// ParseRank parses str and returns its canonical Rank. The empty string parses
+// to the zero Rank.
+func ParseRank(str string) (Rank, error) {
+	if str == "" {
+		return Rank{}, nil
+	}
+	rank, err := LexoRankParse(str)
+	if err != nil {
+		return Rank{}, err
+	}
+	return rank.Value()
+}
+
+// Value returns the rank as a Rank. A nil rank gives the zero Rank. Rank holds
+// LexoRankSystem ranks only, so a rank converted to another numeral system is
+// an error.
+func (i *LexoRank) Value() (Rank, error) {
+	if i == nil {
+		return Rank{}, nil
+	}
+	if sys := i.decimal.GetSystem(); sys.GetBase() != LexoRankSystem.GetBase() {
+		return Rank{}, fmt.Errorf("rank %s is in base %d, not %d", i, sys.GetBase(), LexoRankSystem.GetBase())
+	}
+	return Rank{value: i.value}, nil
+}
+
+// LexoRank returns the rank as a *LexoRank, or nil for the zero Rank.
+func (r Rank) LexoRank() *LexoRank {
+	if r.value == "" {
+		return nil
+	}
+	rank, err := LexoRankParse(r.value)
+	if err != nil {
+		panic(fmt.Sprintf("lexorank: invalid rank value %q: %v", r.value, err))
+	}
+	return rank
+}
+
+func (r Rank) IsZero() bool {
+	return r.value == ""
+}
+
+func (r Rank) String() string {
+	return r.value
+}
+
+// Compare orders ranks like LexoRank.Compare, with the zero Rank first.
+// Canonical strings with buckets of the same length compare as bytes, so only
+// ranks from buckets of different lengths are parsed.
+func (r Rank) Compare(other Rank) int {
+	if strings.IndexByte(r.value, '|') == strings.IndexByte(other.value, '|') {
+		return strings.Compare(r.value, other.value)
+	}
+	return r.LexoRank().Compare(other.LexoRank())
+}
+
+func (r Rank) Between(other Rank) (Rank, error) {
+	rank, err := r.LexoRank().Between(other.LexoRank())
+	if err != nil {
+		return Rank{}, err
+	}
+	return rank.Value()
+}
+
+func (r Rank) Next() (Rank, error) {
+	rank, err := r.LexoRank().Next()
+	if err != nil {
+		return Rank{}, err
+	}
+	return rank.Value()
+}
+
+func (r Rank) Prev() (Rank, error) {
+	rank, err := r.LexoRank().Prev()
+	if err != nil {
+		return Rank{}, err
+	}
+	return rank.Value()
+}
+
+func (r Rank) MarshalText() ([]byte, error) {
+	return []byte(r.value), nil
+}
+
+func (r *Rank) UnmarshalText(text []byte) error {
+	rank, err := ParseRank(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rank
+	return nil
+}
@@ -0,0 +1,128 @@
+package lexorank
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRank(t *testing.T) {
+	left, err := ParseRank("0|100000:")
+	assert.NoError(t, err)
+	right, err := ParseRank("0|100001:")
+	assert.NoError(t, err)
+	mid, err := left.Between(right)
+	assert.NoError(t, err)
+	assert.Equal(t, "0|100000:i", mid.String())
+
+	again, err := ParseRank("0|100000:i")
+	assert.NoError(t, err)
+	assert.True(t, mid == again)
+	seen := map[Rank]bool{mid: true}
+	assert.True(t, seen[again])
+
+	assert.Equal(t, -1, left.Compare(mid))
+	assert.Equal(t, 1, right.Compare(mid))
+	assert.Equal(t, 0, mid.Compare(again))
+
+	canonical, err := ParseRank("0|100000:i0")
+	assert.NoError(t, err)
+	assert.Equal(t, mid, canonical)
+
+	_, err = ParseRank("0|100000:#")
+	assert.Error(t, err)
+}
+
+func TestRank_Zero(t *testing.T) {
+	var zero Rank
+	assert.True(t, zero.IsZero())
+	assert.Nil(t, zero.LexoRank())
+	assert.Equal(t, "", zero.String())
+
+	rank, err := ParseRank("")
+	assert.NoError(t, err)
+	assert.Equal(t, zero, rank)
+	mid, err := MidLexoRank.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, -1, zero.Compare(mid))
+	assert.Equal(t, 1, mid.Compare(zero))
+	assert.Equal(t, 0, zero.Compare(Rank{}))
+
+	_, err = zero.Next()
+	assert.ErrorIs(t, err, NilRankErr)
+	_, err = zero.Between(mid)
+	assert.ErrorIs(t, err, NilRankErr)
+}
+
+func TestRank_Compare(t *testing.T) {
+	for _, tt := range []struct {
+		left, right string
+		want        int
+	}{
+		{left: "0|100000:", right: "0|100000:i", want: -1},
+		{left: "0|z00000:", right: "1|000000:", want: -1},
+		{left: "10|000000:", right: "2|zzzzzz:", want: 1},
+		{left: "2|zzzzzz:", right: "10|000000:", want: -1},
+		{left: "1|hzzzzz:", right: "1|hzzzzz:", want: 0},
+	} {
+		left, err := ParseRank(tt.left)
+		assert.NoError(t, err)
+		right, err := ParseRank(tt.right)
+		assert.NoError(t, err)
+		assert.Equalf(t, tt.want, left.Compare(right), "%s vs %s", tt.left, tt.right)
+		assert.Equalf(t, tt.want, left.LexoRank().Compare(right.LexoRank()), "%s vs %s", tt.left, tt.right)
+	}
+}
+
+func TestLexoRank_ValueOtherSystem(t *testing.T) {
+	decimal, _ := MidLexoRank.GetDecimal().Convert(NewLexoNumeralSystem64())
+	_, err := NewLexoRank(LexoRankBucket0, decimal).Value()
+	assert.Error(t, err)
+}
+
+func TestRank_JSON(t *testing.T) {
+	type item struct {
+		Rank Rank `json:"rank"`
+	}
+	mid, err := MidLexoRank.Value()
+	assert.NoError(t, err)
+	data, err := json.Marshal(item{Rank: mid})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"rank":"0|hzzzzz:"}`, string(data))
+
+	var decoded item
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, mid, decoded.Rank)
+	assert.NoError(t, json.Unmarshal([]byte(`{"rank":""}`), &decoded))
+	assert.True(t, decoded.Rank.IsZero())
+	assert.Error(t, json.Unmarshal([]byte(`{"rank":"x"}`), &decoded))
+}
+
+func TestLexoRank_Nil(t *testing.T) {
+	var rank *LexoRank
+	assert.Equal(t, "", rank.String())
+	assert.False(t, rank.IsMin())
+	assert.False(t, rank.IsMax())
+	assert.Equal(t, -1, rank.Compare(MinLexoRank))
+	assert.Equal(t, 1, MinLexoRank.Compare(rank))
+	_, err := rank.Next()
+	assert.ErrorIs(t, err, NilRankErr)
+	_, err = rank.Prev()
+	assert.ErrorIs(t, err, NilRankErr)
+	_, err = MinLexoRank.Between(rank)
+	assert.ErrorIs(t, err, NilRankErr)
+	_, err = rank.BetweenN(MaxLexoRank, 2)
+	assert.ErrorIs(t, err, NilRankErr)
+}
+
+func TestLexoInteger_NoAliasing(t *testing.T) {
+	mag := []byte{1, 2, 3}
+	value := NewLexoInteger(LexoRankSystem, 1, mag)
+	mag[2] = 9
+	assert.Equal(t, "321", value.String())
+
+	shifted := value.ShiftRight(1)
+	shifted.mag[0] = 7
+	assert.Equal(t, "321", value.String())
+}
@@ -0,0 +1,103 @@
+package lexorank
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortRanked sorts items by the rank returned by key, keeping the order of
+// items with equal ranks. Items without a rank sort first.
+func SortRanked[T any](items []T, key func(T) *LexoRank) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return key(items[i]).Compare(key(items[j])) < 0
+	})
+}
+
+// SearchRanked returns the index at which rank would be inserted into items,
+// which must be sorted by key: the first index whose rank is not less than
+// rank.
+func SearchRanked[T any](items []T, key func(T) *LexoRank, rank *LexoRank) int {
+	return sort.Search(len(items), func(idx int) bool {
+		return key(items[idx]).Compare(rank) >= 0
+	})
+}
+
+// InsertRanked inserts item into items, which must be sorted by key, at index
+// and returns the grown slice together with the rank the item must be given to
+// stay at that index. The item's own rank is not read or changed.
+func InsertRanked[T any](items []T, key func(T) *LexoRank, index int, item T) ([]T, *LexoRank, error) {
+	if index < 0 || index > len(items) {
+		return items, nil, fmt.Errorf("index %d out of range [0, %d]", index, len(items))
+	}
+	var prev, next *LexoRank
+	if index > 0 {
+		prev = key(items[index-1])
+	}
+	if index < len(items) {
+		next = key(items[index])
+	}
+	rank, err := rankBetween(LexoRankBucket0, prev, next)
+	if err != nil {
+		return items, nil, err
+	}
+	var zero T
+	items = append(items, zero)
+	copy(items[index+1:], items[index:])
+	items[index] = item
+	return items, rank, nil
+}
+
+// MoveRanked moves the item at from so that it ends up at index to, shifting
+// the items in between, and returns the rank the moved item must be given.
+// items must be sorted by key before the move.
+func MoveRanked[T any](items []T, key func(T) *LexoRank, from, to int) (*LexoRank, error) {
+	if from < 0 || from >= len(items) {
+		return nil, fmt.Errorf("from index %d out of range [0, %d)", from, len(items))
+	}
+	if to < 0 || to >= len(items) {
+		return nil, fmt.Errorf("to index %d out of range [0, %d)", to, len(items))
+	}
+	if from == to {
+		return key(items[from]), nil
+	}
+	var prev, next *LexoRank
+	if from < to {
+		prev = key(items[to])
+		if to+1 < len(items) {
+			next = key(items[to+1])
+		}
+	} else {
+		if to > 0 {
+			prev = key(items[to-1])
+		}
+		next = key(items[to])
+	}
+	rank, err := rankBetween(LexoRankBucket0, prev, next)
+	if err != nil {
+		return nil, err
+	}
+	item := items[from]
+	if from < to {
+		copy(items[from:to], items[from+1:to+1])
+	} else {
+		copy(items[to+1:from+1], items[to:from])
+	}
+	items[to] = item
+	return rank, nil
+}
+
+// rankBetween ranks between two neighbours, either of which may be nil for the
+// open start or end of the list. An empty list starts in the middle of bucket.
+// Beyond a neighbour at the minimum or maximum rank there is no room, and the
+// error wraps RankBoundErr.
+func rankBetween(bucket *LexoRankBucket, prev, next *LexoRank) (*LexoRank, error) {
+	switch {
+	case prev == nil && next == nil:
+		return MidLexoRank.InBucket(bucket), nil
+	case prev == nil:
+		return next.Prev()
+	case next == nil:
+		return prev.Next()
+	}
+	return prev.Between(next)
+}
@@ -0,0 +1,122 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type card struct {
+	name string
+	rank *LexoRank
+}
+
+func cardRank(c *card) *LexoRank {
+	return c.rank
+}
+
+func cardNames(cards []*card) []string {
+	names := make([]string, len(cards))
+	for idx, c := range cards {
+		names[idx] = c.name
+	}
+	return names
+}
+
+func newCards(t *testing.T, ranks ...string) []*card {
+	cards := make([]*card, len(ranks))
+	for idx, rank := range parseRanks(t, ranks...) {
+		cards[idx] = &card{name: string(rune('a' + idx)), rank: rank}
+	}
+	return cards
+}
+
+func TestSortRanked(t *testing.T) {
+	cards := newCards(t, "0|100002:", "0|100000:", "0|100001:", "0|100000:")
+	SortRanked(cards, cardRank)
+	assert.Equal(t, []string{"b", "d", "c", "a"}, cardNames(cards))
+}
+
+func TestSearchRanked(t *testing.T) {
+	cards := newCards(t, "0|100000:", "0|100001:", "0|100002:")
+	for value, want := range map[string]int{
+		"0|000001:":  0,
+		"0|100000:":  0,
+		"0|100000:i": 1,
+		"0|100002:":  2,
+		"0|zzzzzz:":  3,
+	} {
+		assert.Equalf(t, want, SearchRanked(cards, cardRank, parseRanks(t, value)[0]), "SearchRanked(%s)", value)
+	}
+}
+
+func TestInsertRanked(t *testing.T) {
+	var cards []*card
+	for _, step := range []struct {
+		index int
+		name  string
+		want  string
+	}{
+		{index: 0, name: "a", want: "0|hzzzzz:"},
+		{index: 1, name: "b", want: "0|i00007:"},
+		{index: 0, name: "c", want: "0|hzzzzr:"},
+		{index: 1, name: "d", want: "0|hzzzzv:"},
+	} {
+		var rank *LexoRank
+		var err error
+		item := &card{name: step.name}
+		cards, rank, err = InsertRanked(cards, cardRank, step.index, item)
+		require.NoError(t, err)
+		assert.Equal(t, step.want, rank.String())
+		item.rank = rank
+	}
+	assert.Equal(t, []string{"c", "d", "a", "b"}, cardNames(cards))
+	_, _, err := InsertRanked(cards, cardRank, 5, &card{})
+	assert.Error(t, err)
+}
+
+func TestMoveRanked(t *testing.T) {
+	cards := newCards(t, "0|100000:", "0|100001:", "0|100002:", "0|100003:")
+	tests := []struct {
+		from  int
+		to    int
+		want  string
+		names []string
+	}{
+		{from: 0, to: 2, want: "0|100002:i", names: []string{"b", "c", "a", "d"}},
+		{from: 3, to: 0, want: "0|0zzzzt:", names: []string{"d", "b", "c", "a"}},
+		{from: 1, to: 3, want: "0|10000b:", names: []string{"d", "c", "a", "b"}},
+		{from: 2, to: 2, want: "0|100002:i", names: []string{"d", "c", "a", "b"}},
+	}
+	for _, tt := range tests {
+		rank, err := MoveRanked(cards, cardRank, tt.from, tt.to)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, rank.String())
+		cards[tt.to].rank = rank
+		assert.Equal(t, tt.names, cardNames(cards))
+	}
+	for idx := 1; idx < len(cards); idx++ {
+		assert.Equal(t, -1, cards[idx-1].rank.Compare(cards[idx].rank))
+	}
+	_, err := MoveRanked(cards, cardRank, 0, 4)
+	assert.Error(t, err)
+}
+
+func TestRanked_Bounds(t *testing.T) {
+	cards := newCards(t, "0|000000:", "0|hzzzzz:", "0|zzzzzz:")
+
+	_, _, err := InsertRanked(cards, cardRank, 0, &card{name: "x"})
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, _, err = InsertRanked(cards, cardRank, 3, &card{name: "x"})
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = MoveRanked(cards, cardRank, 1, 0)
+	assert.ErrorIs(t, err, RankBoundErr)
+	_, err = MoveRanked(cards, cardRank, 1, 2)
+	assert.ErrorIs(t, err, RankBoundErr)
+	assert.Equal(t, []string{"a", "b", "c"}, cardNames(cards))
+
+	_, rank, err := InsertRanked(cards, cardRank, 1, &card{name: "x"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rank.Compare(cards[0].rank))
+}
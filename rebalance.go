@@ -0,0 +1,115 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	RanksNotSortedErr   = errors.New("ranks are not strictly ascending")
+	RanksMixedBucketErr = errors.New("ranks belong to different buckets")
+)
+
+// RebalanceWindow replaces ranks[Start:End] with Ranks.
+type RebalanceWindow struct {
+	Start int
+	End   int
+	Ranks []*LexoRank
+}
+
+// RebalanceWindows finds the smallest windows of sorted ranks to rewrite so that
+// no rank exceeds maxLength and each window fills at most density of its gap.
+func RebalanceWindows(ranks []*LexoRank, maxLength int, density float64) ([]RebalanceWindow, error) {
+	if density <= 0 || density > 1 {
+		return nil, fmt.Errorf("density out of range (0, 1]: %v", density)
+	}
+	if len(ranks) == 0 {
+		return nil, nil
+	}
+	bucket, err := checkAscending(ranks)
+	if err != nil {
+		return nil, err
+	}
+	scale := maxScale(bucket, maxLength)
+	if scale < 0 {
+		return nil, fmt.Errorf("max length too small: %d", maxLength)
+	}
+
+	at := func(idx int) *LexoRank { return ranks[idx] }
+	var windows []RebalanceWindow
+	for idx := 0; idx < len(ranks); idx++ {
+		if len(ranks[idx].String()) <= maxLength {
+			continue
+		}
+		start, end := idx, idx+1
+		for end < len(ranks) && len(ranks[end].String()) > maxLength {
+			end++
+		}
+		window, err := expandWindow(at, len(ranks), bucket, start, end, scale, density)
+		if err != nil {
+			return nil, err
+		}
+		for last := len(windows) - 1; last >= 0 && window.Start <= windows[last].End; last-- {
+			window, err = expandWindow(at, len(ranks), bucket, windows[last].Start, window.End, scale, density)
+			if err != nil {
+				return nil, err
+			}
+			windows = windows[:last]
+		}
+		windows = append(windows, window)
+		idx = window.End - 1
+	}
+	return windows, nil
+}
+
+// checkAscending returns the bucket of non-empty ranks after checking that
+// they share it and ascend strictly.
+func checkAscending(ranks []*LexoRank) (*LexoRankBucket, error) {
+	bucket := ranks[0].bucket
+	for idx := 1; idx < len(ranks); idx++ {
+		if !ranks[idx].bucket.Equals(bucket) {
+			return nil, RanksMixedBucketErr
+		}
+		if ranks[idx-1].decimal.Compare(ranks[idx].decimal) >= 0 {
+			return nil, fmt.Errorf("%w: %s >= %s", RanksNotSortedErr, ranks[idx-1], ranks[idx])
+		}
+	}
+	return bucket, nil
+}
+
+// expandWindow grows [start, end) of the n ranks returned by at until the
+// window fits into its outer bounds at the given scale and density.
+func expandWindow(at func(int) *LexoRank, n int, bucket *LexoRankBucket, start, end, scale int, density float64) (RebalanceWindow, error) {
+	growLeft := true
+	for {
+		low, high := windowBounds(at, n, bucket, start, end)
+		need := end - start
+		if float64(low.Capacity(high, scale))*density >= float64(need) {
+			window := RebalanceWindow{Start: start, End: end, Ranks: make([]*LexoRank, need)}
+			for idx, decimal := range low.spread(high, need, density) {
+				window.Ranks[idx] = NewLexoRank(bucket, decimal)
+			}
+			return window, nil
+		}
+		switch {
+		case start == 0 && end == n:
+			return RebalanceWindow{}, fmt.Errorf("%d ranks do not fit into scale %d", need, scale)
+		case start > 0 && (end == n || growLeft && (start > 1 || end+1 == n)):
+			start--
+		default:
+			end++
+		}
+		growLeft = !growLeft
+	}
+}
+
+func windowBounds(at func(int) *LexoRank, n int, bucket *LexoRankBucket, start, end int) (*LexoDecimal, *LexoDecimal) {
+	low, high := minLexoRank(bucket).decimal, maxLexoRank(bucket).decimal
+	if start > 0 {
+		low = at(start - 1).decimal
+	}
+	if end < n {
+		high = at(end).decimal
+	}
+	return low, high
+}
@@ -0,0 +1,71 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebalanceWindows(t *testing.T) {
+	tests := []struct {
+		name      string
+		ranks     []string
+		maxLength int
+		density   float64
+		want      []RebalanceWindow
+	}{
+		{
+			name:      "short ranks",
+			ranks:     []string{"0|100000:", "0|100001:", "0|100002:"},
+			maxLength: 9,
+			density:   1,
+			want:      nil,
+		},
+		{
+			name:      "single long rank",
+			ranks:     []string{"0|100000:", "0|100000:i", "0|100002:"},
+			maxLength: 9,
+			density:   1,
+			want: []RebalanceWindow{
+				{Start: 1, End: 2, Ranks: parseRanks(t, "0|100001:")},
+			},
+		},
+		{
+			name:      "hot spot grows window",
+			ranks:     []string{"0|100000:", "0|100000:i", "0|100000:r", "0|100001:", "0|100005:"},
+			maxLength: 9,
+			density:   1,
+			want: []RebalanceWindow{
+				{Start: 1, End: 4, Ranks: parseRanks(t, "0|100001:", "0|100002:", "0|100003:")},
+			},
+		},
+		{
+			name:      "density leaves room",
+			ranks:     []string{"0|100000:", "0|100000:i", "0|100008:"},
+			maxLength: 9,
+			density:   0.5,
+			want: []RebalanceWindow{
+				{Start: 1, End: 2, Ranks: parseRanks(t, "0|100004:")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RebalanceWindows(parseRanks(t, tt.ranks...), tt.maxLength, tt.density)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "RebalanceWindows(%v)", tt.ranks)
+		})
+	}
+}
+
+func TestRebalanceWindows_Errors(t *testing.T) {
+	ranks := parseRanks(t, "0|100001:", "0|100000:", "1|100002:")
+	first, second, other := ranks[0], ranks[1], ranks[2]
+
+	_, err := RebalanceWindows([]*LexoRank{first, second}, 9, 1)
+	assert.ErrorIs(t, err, RanksNotSortedErr)
+	_, err = RebalanceWindows([]*LexoRank{first, other}, 9, 1)
+	assert.ErrorIs(t, err, RanksMixedBucketErr)
+	_, err = RebalanceWindows([]*LexoRank{first}, 9, 0)
+	assert.Error(t, err)
+}
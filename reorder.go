@@ -0,0 +1,123 @@
+package lexorank
+
+import (
+	"fmt"
+	"sort"
+)
+
+type RankedItem[K comparable] struct {
+	ID   K
+	Rank *LexoRank
+}
+
+// ReorderDiff returns new ranks for the fewest items of current needed to put
+// them in the given order. Items on a longest increasing run keep their ranks.
+func ReorderDiff[K comparable](current []RankedItem[K], order []K) ([]RankedItem[K], error) {
+	if len(current) != len(order) {
+		return nil, fmt.Errorf("order has %d ids, expected %d", len(order), len(current))
+	}
+	if len(current) == 0 {
+		return nil, nil
+	}
+	byID := make(map[K]*LexoRank, len(current))
+	for _, item := range current {
+		if _, ok := byID[item.ID]; ok {
+			return nil, fmt.Errorf("duplicate id: %v", item.ID)
+		}
+		if item.Rank == nil {
+			return nil, fmt.Errorf("id %v has no rank", item.ID)
+		}
+		byID[item.ID] = item.Rank
+	}
+	ranks := make([]*LexoRank, len(order))
+	for idx, id := range order {
+		rank, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown or repeated id: %v", id)
+		}
+		ranks[idx] = rank
+		delete(byID, id)
+	}
+	bucket := ranks[0].bucket
+	for _, rank := range ranks {
+		if !rank.bucket.Equals(bucket) {
+			return nil, RanksMixedBucketErr
+		}
+	}
+	planned, err := planRanks(ranks, bucket)
+	if err != nil {
+		return nil, err
+	}
+	var updates []RankedItem[K]
+	for idx, rank := range planned {
+		if rank != nil {
+			updates = append(updates, RankedItem[K]{ID: order[idx], Rank: rank})
+		}
+	}
+	return updates, nil
+}
+
+// planRanks keeps the longest strictly increasing subsequence of ranks in
+// bucket and returns new ranks for every other position, nil where kept.
+func planRanks(ranks []*LexoRank, bucket *LexoRankBucket) ([]*LexoRank, error) {
+	minRank, maxRank := minLexoRank(bucket), maxLexoRank(bucket)
+	keep := make([]bool, len(ranks))
+	for _, idx := range increasingRun(ranks, func(rank *LexoRank) bool {
+		return rank != nil && rank.bucket.Equals(bucket) && rank.Compare(minRank) > 0 && rank.Compare(maxRank) < 0
+	}) {
+		keep[idx] = true
+	}
+	planned := make([]*LexoRank, len(ranks))
+	low, start := minRank, 0
+	for idx := 0; idx <= len(ranks); idx++ {
+		if idx < len(ranks) && !keep[idx] {
+			continue
+		}
+		high := maxRank
+		if idx < len(ranks) {
+			high = ranks[idx]
+		}
+		if idx > start {
+			between, err := low.BetweenN(high, idx-start)
+			if err != nil {
+				return nil, fmt.Errorf("plan ranks: %w", err)
+			}
+			copy(planned[start:idx], between)
+		}
+		low, start = high, idx+1
+	}
+	return planned, nil
+}
+
+func increasingRun(ranks []*LexoRank, usable func(*LexoRank) bool) []int {
+	var tails []int
+	prev := make([]int, len(ranks))
+	for idx, rank := range ranks {
+		if !usable(rank) {
+			continue
+		}
+		pos := sort.Search(len(tails), func(i int) bool {
+			return ranks[tails[i]].Compare(rank) >= 0
+		})
+		if pos < len(tails) && ranks[tails[pos]].Compare(rank) == 0 {
+			continue
+		}
+		prev[idx] = -1
+		if pos > 0 {
+			prev[idx] = tails[pos-1]
+		}
+		if pos == len(tails) {
+			tails = append(tails, idx)
+		} else {
+			tails[pos] = idx
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	run := make([]int, len(tails))
+	for idx, pos := tails[len(tails)-1], len(tails)-1; pos >= 0; idx, pos = prev[idx], pos-1 {
+		run[pos] = idx
+	}
+	return run
+}
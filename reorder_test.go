@@ -0,0 +1,62 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorderDiff(t *testing.T) {
+	ranks := parseRanks(t, "0|100000:", "0|100001:", "0|100002:", "0|100003:", "0|100004:")
+	current := []RankedItem[string]{
+		{ID: "a", Rank: ranks[0]},
+		{ID: "b", Rank: ranks[1]},
+		{ID: "c", Rank: ranks[2]},
+		{ID: "d", Rank: ranks[3]},
+		{ID: "e", Rank: ranks[4]},
+	}
+	tests := []struct {
+		name  string
+		order []string
+		want  []RankedItem[string]
+	}{
+		{
+			name:  "unchanged",
+			order: []string{"a", "b", "c", "d", "e"},
+			want:  nil,
+		},
+		{
+			name:  "move to top",
+			order: []string{"e", "a", "b", "c", "d"},
+			want:  []RankedItem[string]{{ID: "e", Rank: parseRanks(t, "0|0i0000:")[0]}},
+		},
+		{
+			name:  "swap",
+			order: []string{"a", "c", "b", "d", "e"},
+			want:  []RankedItem[string]{{ID: "c", Rank: parseRanks(t, "0|100000:i")[0]}},
+		},
+		{
+			name:  "move to bottom",
+			order: []string{"b", "c", "d", "e", "a"},
+			want:  []RankedItem[string]{{ID: "a", Rank: parseRanks(t, "0|ii0001:")[0]}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReorderDiff(current, tt.order)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "ReorderDiff(%v)", tt.order)
+		})
+	}
+}
+
+func TestReorderDiff_Errors(t *testing.T) {
+	current := []RankedItem[int]{{ID: 1, Rank: parseRanks(t, "0|100000:")[0]}, {ID: 2, Rank: MidLexoRank}}
+
+	_, err := ReorderDiff(current, []int{1})
+	assert.Error(t, err)
+	_, err = ReorderDiff(current, []int{1, 1})
+	assert.Error(t, err)
+	_, err = ReorderDiff(current, []int{1, 3})
+	assert.Error(t, err)
+}
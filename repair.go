@@ -0,0 +1,81 @@
+package lexorank
+
+import (
+	"sort"
+)
+
+type RawRankedItem[K comparable] struct {
+	ID   K
+	Rank string
+}
+
+type RepairPlan[K comparable] struct {
+	Bucket     *LexoRankBucket
+	Duplicates [][]K
+	Invalid    []K
+	Foreign    []K
+	Updates    []RankedItem[K]
+}
+
+// PlanRepair restores a strict order over items given in tiebreaker order.
+// Items are ordered by rank, then by their input position; unparseable ranks go
+// last. Only items off the longest increasing run get new ranks in Bucket,
+// which is the bucket most of the items already use.
+func PlanRepair[K comparable](items []RawRankedItem[K]) (*RepairPlan[K], error) {
+	plan := &RepairPlan[K]{Bucket: LexoRankBucket0}
+	var ordered, invalid []RankedItem[K]
+	buckets := make(map[string]int)
+	for _, item := range items {
+		rank, err := LexoRankParse(item.Rank)
+		if err != nil {
+			plan.Invalid = append(plan.Invalid, item.ID)
+			invalid = append(invalid, RankedItem[K]{ID: item.ID})
+			continue
+		}
+		ordered = append(ordered, RankedItem[K]{ID: item.ID, Rank: rank})
+		buckets[rank.bucket.String()]++
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Rank.Compare(ordered[j].Rank) < 0
+	})
+	for _, item := range ordered {
+		count, best := buckets[item.Rank.bucket.String()], buckets[plan.Bucket.String()]
+		if count > best || count == best && item.Rank.bucket.value.Compare(plan.Bucket.value) < 0 {
+			plan.Bucket = item.Rank.bucket
+		}
+	}
+	for idx := 0; idx < len(ordered); {
+		end := idx + 1
+		for end < len(ordered) && ordered[end].Rank.Compare(ordered[idx].Rank) == 0 {
+			end++
+		}
+		if end-idx > 1 {
+			group := make([]K, 0, end-idx)
+			for _, item := range ordered[idx:end] {
+				group = append(group, item.ID)
+			}
+			plan.Duplicates = append(plan.Duplicates, group)
+		}
+		for _, item := range ordered[idx:end] {
+			if !item.Rank.bucket.Equals(plan.Bucket) {
+				plan.Foreign = append(plan.Foreign, item.ID)
+			}
+		}
+		idx = end
+	}
+	ordered = append(ordered, invalid...)
+	ranks := make([]*LexoRank, len(ordered))
+	for idx, item := range ordered {
+		ranks[idx] = item.Rank
+	}
+	planned, err := planRanks(ranks, plan.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	for idx, rank := range planned {
+		if rank != nil {
+			plan.Updates = append(plan.Updates, RankedItem[K]{ID: ordered[idx].ID, Rank: rank})
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,63 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanRepair(t *testing.T) {
+	updates := parseRanks(t, "0|100000:i", "0|co0000:", "0|obzzzz:")
+	tests := []struct {
+		name  string
+		items []RawRankedItem[int]
+		want  *RepairPlan[int]
+	}{
+		{
+			name: "ordered",
+			items: []RawRankedItem[int]{
+				{ID: 1, Rank: "0|100000:"},
+				{ID: 2, Rank: "0|100001:"},
+			},
+			want: &RepairPlan[int]{Bucket: LexoRankBucket0},
+		},
+		{
+			name: "duplicates",
+			items: []RawRankedItem[int]{
+				{ID: 1, Rank: "0|100000:"},
+				{ID: 2, Rank: "0|100000:"},
+				{ID: 3, Rank: "0|100001:"},
+			},
+			want: &RepairPlan[int]{
+				Bucket:     LexoRankBucket0,
+				Duplicates: [][]int{{1, 2}},
+				Updates:    []RankedItem[int]{{ID: 2, Rank: updates[0]}},
+			},
+		},
+		{
+			name: "invalid and foreign",
+			items: []RawRankedItem[int]{
+				{ID: 1, Rank: "0|100000:"},
+				{ID: 2, Rank: "garbage"},
+				{ID: 3, Rank: "1|000001:"},
+				{ID: 4, Rank: "0|100001:"},
+			},
+			want: &RepairPlan[int]{
+				Bucket:  LexoRankBucket0,
+				Invalid: []int{2},
+				Foreign: []int{3},
+				Updates: []RankedItem[int]{
+					{ID: 3, Rank: updates[1]},
+					{ID: 2, Rank: updates[2]},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanRepair(tt.items)
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "PlanRepair(%v)", tt.items)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ReplicaRankCollisionErr = errors.New("different items share a replica rank")
+
+const (
+	replicaSeparator     = '!'
+	replicaPartSeparator = ' '
+)
+
+// ReplicaPart is one step of a ReplicaRank path: a rank and the replica that
+// generated it. Parts of plain ranks have an empty Replica.
+type ReplicaPart struct {
+	Rank    *LexoRank
+	Replica string
+}
+
+func (p ReplicaPart) compare(other ReplicaPart) int {
+	if cmp := p.Rank.Compare(other.Rank); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(p.Replica, other.Replica)
+}
+
+// ReplicaRank is a Logoot-style position for collaborative editing: a path of
+// (rank, replica) parts compared part by part, a path sorting before its own
+// extensions. Two replicas that compute the same Between rank offline still get
+// distinct positions, ordered by replica, and a plain LexoRank is a one-part
+// path without replica, so existing ranks stay valid.
+//
+// The string form joins parts with ' ' and writes each part as rank!replica.
+// As long as replicas use only the characters '"' to '~', comparing strings as
+// bytes agrees with Compare.
+type ReplicaRank struct {
+	parts []ReplicaPart
+}
+
+// NewReplicaRank wraps a plain rank.
+func NewReplicaRank(rank *LexoRank) (*ReplicaRank, error) {
+	if rank == nil {
+		return nil, NilRankErr
+	}
+	return &ReplicaRank{parts: []ReplicaPart{{Rank: rank}}}, nil
+}
+
+func ParseReplicaRank(str string) (*ReplicaRank, error) {
+	fields := strings.Split(str, string(replicaPartSeparator))
+	parts := make([]ReplicaPart, len(fields))
+	for idx, field := range fields {
+		value, replica, found := strings.Cut(field, string(replicaSeparator))
+		if found {
+			if err := validateReplica(replica); err != nil {
+				return nil, err
+			}
+		}
+		rank, err := LexoRankParse(value)
+		if err != nil {
+			return nil, err
+		}
+		if idx > 0 && !rank.bucket.Equals(parts[0].Rank.bucket) {
+			return nil, RanksMixedBucketErr
+		}
+		parts[idx] = ReplicaPart{Rank: rank, Replica: replica}
+	}
+	return &ReplicaRank{parts: parts}, nil
+}
+
+func validateReplica(replica string) error {
+	if replica == "" {
+		return errors.New("empty replica")
+	}
+	for idx := 0; idx < len(replica); idx++ {
+		if ch := replica[idx]; ch <= replicaSeparator || ch > '~' {
+			return fmt.Errorf("invalid replica character %q in %q", ch, replica)
+		}
+	}
+	return nil
+}
+
+// Parts returns a copy of the path.
+func (r *ReplicaRank) Parts() []ReplicaPart {
+	return append([]ReplicaPart(nil), r.parts...)
+}
+
+func (r *ReplicaRank) String() string {
+	var sb strings.Builder
+	for idx, part := range r.parts {
+		if idx > 0 {
+			sb.WriteByte(replicaPartSeparator)
+		}
+		sb.WriteString(part.Rank.String())
+		if part.Replica != "" {
+			sb.WriteByte(replicaSeparator)
+			sb.WriteString(part.Replica)
+		}
+	}
+	return sb.String()
+}
+
+func (r *ReplicaRank) Compare(other *ReplicaRank) int {
+	for idx := 0; idx < len(r.parts) && idx < len(other.parts); idx++ {
+		if cmp := r.parts[idx].compare(other.parts[idx]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(r.parts) < len(other.parts):
+		return -1
+	case len(r.parts) > len(other.parts):
+		return 1
+	}
+	return 0
+}
+
+// ReplicaRankBetween returns a position strictly between prev and next for an
+// insert made by replica. A nil prev or next stands for the open start or end.
+// Only the first part that differs is changed, with LexoRank.Between or with
+// Next and Prev at an open end, and the path grows only when prev and next tie
+// on a rank.
+func ReplicaRankBetween(prev, next *ReplicaRank, replica string) (*ReplicaRank, error) {
+	if err := validateReplica(replica); err != nil {
+		return nil, err
+	}
+	var lo, hi []ReplicaPart
+	bucket := LexoRankBucket0
+	if next != nil {
+		hi = next.parts
+		bucket = next.parts[0].Rank.bucket
+	}
+	if prev != nil {
+		lo = prev.parts
+		bucket = prev.parts[0].Rank.bucket
+	}
+	if prev != nil && next != nil {
+		if !prev.parts[0].Rank.bucket.Equals(next.parts[0].Rank.bucket) {
+			return nil, errors.New("between works only within the same bucket")
+		}
+		if prev.Compare(next) >= 0 {
+			return nil, fmt.Errorf("%s is not less than %s", prev, next)
+		}
+	}
+	parts, err := replicaBetween(bucket, lo, hi, replica)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplicaRank{parts: parts}, nil
+}
+
+// replicaBetween builds a path above lo and below hi, where an empty lo or hi
+// leaves that side open.
+func replicaBetween(bucket *LexoRankBucket, lo, hi []ReplicaPart, replica string) ([]ReplicaPart, error) {
+	loRank, hiRank := minLexoRank(bucket), maxLexoRank(bucket)
+	if len(lo) > 0 {
+		loRank = lo[0].Rank
+	}
+	if len(hi) > 0 {
+		hiRank = hi[0].Rank
+	}
+	var rest []ReplicaPart
+	var err error
+	switch cmp := loRank.Compare(hiRank); {
+	case cmp < 0:
+		var prev, next *LexoRank
+		if len(lo) > 0 {
+			prev = lo[0].Rank
+		}
+		if len(hi) > 0 {
+			next = hi[0].Rank
+		}
+		rank, err := rankBetween(bucket, prev, next)
+		if err != nil {
+			return nil, err
+		}
+		return []ReplicaPart{{Rank: rank, Replica: replica}}, nil
+	case cmp > 0 || len(lo) == 0:
+		return nil, fmt.Errorf("no room below %s", hiRank)
+	case len(hi) == 0 || lo[0].Replica < hi[0].Replica:
+		rest, err = replicaBetween(bucket, lo[1:], nil, replica)
+	case lo[0].Replica == hi[0].Replica:
+		rest, err = replicaBetween(bucket, lo[1:], hi[1:], replica)
+	default:
+		return nil, fmt.Errorf("no room between replicas %q and %q", lo[0].Replica, hi[0].Replica)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]ReplicaPart{lo[0]}, rest...), nil
+}
+
+// ReplicaVersion is a Lamport timestamp for a write to a ReplicaItem. A replica
+// stamps each insert, move or delete with a Counter above every counter it has
+// seen, and the Replica breaks ties between concurrent writes. The zero version
+// is older than every stamped write.
+type ReplicaVersion struct {
+	Counter uint64
+	Replica string
+}
+
+func (v ReplicaVersion) Compare(other ReplicaVersion) int {
+	switch {
+	case v.Counter < other.Counter:
+		return -1
+	case v.Counter > other.Counter:
+		return 1
+	}
+	return strings.Compare(v.Replica, other.Replica)
+}
+
+// ReplicaItem is an item positioned by a ReplicaRank. Version stamps the write
+// that set Rank or Deleted; a deleted item is a tombstone that keeps its last
+// rank so that merging with an older copy does not bring it back.
+type ReplicaItem[K comparable] struct {
+	ID      K
+	Rank    *ReplicaRank
+	Version ReplicaVersion
+	Deleted bool
+}
+
+// NextReplicaVersion returns the version for the next write by replica: one
+// above the greatest counter in items.
+func NextReplicaVersion[K comparable](items []ReplicaItem[K], replica string) ReplicaVersion {
+	var counter uint64
+	for _, item := range items {
+		if item.Version.Counter > counter {
+			counter = item.Version.Counter
+		}
+	}
+	return ReplicaVersion{Counter: counter + 1, Replica: replica}
+}
+
+// MergeReplicaItems combines the sequences of two replicas into one sequence
+// ordered by rank. An item present in both keeps the copy with the newer
+// version, so the last move wins whichever direction it went and a delete wins
+// over older moves. Merging is commutative, associative and idempotent and
+// every replica converges on the same order. Tombstones stay in the result,
+// ordered by rank and then version; only live items must have distinct ranks.
+func MergeReplicaItems[K comparable](left, right []ReplicaItem[K]) ([]ReplicaItem[K], error) {
+	byID := make(map[K]ReplicaItem[K], len(left)+len(right))
+	for _, items := range [][]ReplicaItem[K]{left, right} {
+		for _, item := range items {
+			if item.Rank == nil {
+				return nil, fmt.Errorf("id %v has no rank", item.ID)
+			}
+			current, ok := byID[item.ID]
+			if !ok {
+				byID[item.ID] = item
+				continue
+			}
+			switch cmp := current.Version.Compare(item.Version); {
+			case cmp < 0:
+				byID[item.ID] = item
+			case cmp == 0 && (current.Deleted != item.Deleted || current.Rank.Compare(item.Rank) != 0):
+				return nil, fmt.Errorf("id %v has two different writes at version %d!%s", item.ID, item.Version.Counter, item.Version.Replica)
+			}
+		}
+	}
+	merged := make([]ReplicaItem[K], 0, len(byID))
+	for _, item := range byID {
+		merged = append(merged, item)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if cmp := merged[i].Rank.Compare(merged[j].Rank); cmp != 0 {
+			return cmp < 0
+		}
+		return merged[i].Version.Compare(merged[j].Version) < 0
+	})
+	var live *ReplicaItem[K]
+	for idx := range merged {
+		item := &merged[idx]
+		if idx > 0 && merged[idx-1].Rank.Compare(item.Rank) == 0 && merged[idx-1].Version.Compare(item.Version) == 0 {
+			return nil, fmt.Errorf("%w: %v and %v at %s", ReplicaRankCollisionErr, merged[idx-1].ID, item.ID, item.Rank)
+		}
+		if item.Deleted {
+			continue
+		}
+		if live != nil && live.Rank.Compare(item.Rank) == 0 {
+			return nil, fmt.Errorf("%w: %v and %v at %s", ReplicaRankCollisionErr, live.ID, item.ID, item.Rank)
+		}
+		live = item
+	}
+	return merged, nil
+}
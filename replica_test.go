@@ -0,0 +1,201 @@
+package lexorank
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustReplicaRank(t *testing.T, str string) *ReplicaRank {
+	rank, err := ParseReplicaRank(str)
+	require.NoError(t, err)
+	return rank
+}
+
+func TestReplicaRankBetween(t *testing.T) {
+	tests := []struct {
+		prev    string
+		next    string
+		replica string
+		want    string
+		wantErr bool
+	}{
+		{replica: "a", want: "0|hzzzzz:!a"},
+		{prev: "0|100000:", next: "0|100001:", replica: "a", want: "0|100000:i!a"},
+		{prev: "0|100000:i!a", next: "0|100000:i!b", replica: "c", want: "0|100000:i!a 0|hzzzzz:!c"},
+		{prev: "0|100000:i!a 0|hzzzzz:!c", next: "0|100000:i!b", replica: "c", want: "0|100000:i!a 0|i00007:!c"},
+		{prev: "0|100000:i!a", next: "0|100000:i!a 0|100000:!b", replica: "c", want: "0|100000:i!a 0|0zzzzs:!c"},
+		{prev: "0|100000:i", next: "0|100000:i!a", replica: "c", want: "0|100000:i 0|hzzzzz:!c"},
+		{prev: "0|zzzzzz:", replica: "a", want: "0|zzzzzz: 0|hzzzzz:!a"},
+		{next: "0|000000:", replica: "a", wantErr: true},
+		{prev: "0|100001:", next: "0|100000:", replica: "a", wantErr: true},
+		{prev: "0|100000:", next: "0|100001:", replica: "a b", wantErr: true},
+		{prev: "0|100000:", next: "0|100001:", replica: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prev+"_"+tt.next, func(t *testing.T) {
+			var prev, next *ReplicaRank
+			if tt.prev != "" {
+				prev = mustReplicaRank(t, tt.prev)
+			}
+			if tt.next != "" {
+				next = mustReplicaRank(t, tt.next)
+			}
+			got, err := ReplicaRankBetween(prev, next, tt.replica)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			if prev != nil {
+				assert.Equal(t, -1, prev.Compare(got))
+			}
+			if next != nil {
+				assert.Equal(t, -1, got.Compare(next))
+			}
+		})
+	}
+}
+
+func TestReplicaRank_Concurrent(t *testing.T) {
+	prev := mustReplicaRank(t, "0|100000:")
+	next := mustReplicaRank(t, "0|100001:")
+	fromA, err := ReplicaRankBetween(prev, next, "site-a")
+	require.NoError(t, err)
+	fromB, err := ReplicaRankBetween(prev, next, "site-b")
+	require.NoError(t, err)
+	assert.Equal(t, -1, fromA.Compare(fromB))
+
+	ranks := []*ReplicaRank{prev, fromA, fromB, next}
+	rnd := rand.New(rand.NewSource(1))
+	for idx := 0; idx < 500; idx++ {
+		pos := rnd.Intn(len(ranks) - 1)
+		rank, err := ReplicaRankBetween(ranks[pos], ranks[pos+1], []string{"site-a", "site-b", "c"}[rnd.Intn(3)])
+		require.NoError(t, err)
+		ranks = append(ranks[:pos+1], append([]*ReplicaRank{rank}, ranks[pos+1:]...)...)
+	}
+	strs := make([]string, len(ranks))
+	for idx, rank := range ranks {
+		strs[idx] = rank.String()
+		if idx > 0 {
+			assert.Equal(t, -1, ranks[idx-1].Compare(rank))
+		}
+		assert.Equal(t, 0, mustReplicaRank(t, strs[idx]).Compare(rank))
+	}
+	assert.True(t, sort.StringsAreSorted(strs))
+}
+
+func replicaIDs(items []ReplicaItem[string]) string {
+	var sb strings.Builder
+	for _, item := range items {
+		if !item.Deleted {
+			sb.WriteString(item.ID)
+		}
+	}
+	return sb.String()
+}
+
+func TestMergeReplicaItems(t *testing.T) {
+	left := []ReplicaItem[string]{
+		{ID: "x", Rank: mustReplicaRank(t, "0|100000:")},
+		{ID: "a", Rank: mustReplicaRank(t, "0|100000:i!a")},
+		{ID: "y", Rank: mustReplicaRank(t, "0|100001:")},
+	}
+	right := []ReplicaItem[string]{
+		{ID: "x", Rank: mustReplicaRank(t, "0|100000:")},
+		{ID: "b", Rank: mustReplicaRank(t, "0|100000:i!b")},
+		{ID: "y", Rank: mustReplicaRank(t, "0|100002:!b"), Version: ReplicaVersion{Counter: 1, Replica: "b"}},
+	}
+	merged, err := MergeReplicaItems(left, right)
+	require.NoError(t, err)
+	assert.Equal(t, "xaby", replicaIDs(merged))
+	assert.Equal(t, "0|100002:!b", merged[3].Rank.String())
+
+	swapped, err := MergeReplicaItems(right, left)
+	require.NoError(t, err)
+	assert.Equal(t, merged, swapped)
+	again, err := MergeReplicaItems(merged, left)
+	require.NoError(t, err)
+	assert.Equal(t, merged, again)
+
+	_, err = MergeReplicaItems(left, []ReplicaItem[string]{{ID: "z", Rank: mustReplicaRank(t, "0|100000:i!a")}})
+	assert.ErrorIs(t, err, ReplicaRankCollisionErr)
+}
+
+func TestMergeReplicaItems_Moves(t *testing.T) {
+	base := []ReplicaItem[string]{
+		{ID: "x", Rank: mustReplicaRank(t, "0|100000:")},
+		{ID: "y", Rank: mustReplicaRank(t, "0|100001:")},
+		{ID: "z", Rank: mustReplicaRank(t, "0|100002:")},
+	}
+	moveUp := []ReplicaItem[string]{
+		{ID: "y", Rank: mustReplicaRank(t, "0|000001:!a"), Version: NextReplicaVersion(base, "a")},
+		base[0], base[2],
+	}
+	moveDown := []ReplicaItem[string]{
+		base[1], base[2],
+		{ID: "x", Rank: mustReplicaRank(t, "0|100003:!b"), Version: NextReplicaVersion(base, "b")},
+	}
+
+	for _, tt := range []struct {
+		name  string
+		moved []ReplicaItem[string]
+		want  string
+	}{
+		{name: "up", moved: moveUp, want: "yxz"},
+		{name: "down", moved: moveDown, want: "yzx"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeReplicaItems(base, tt.moved)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, replicaIDs(merged))
+			swapped, err := MergeReplicaItems(tt.moved, base)
+			require.NoError(t, err)
+			assert.Equal(t, merged, swapped)
+		})
+	}
+
+	both, err := MergeReplicaItems(moveUp, moveDown)
+	require.NoError(t, err)
+	assert.Equal(t, "yzx", replicaIDs(both))
+
+	again := []ReplicaItem[string]{{ID: "y", Rank: mustReplicaRank(t, "0|100004:!b"), Version: NextReplicaVersion(moveUp, "b")}}
+	merged, err := MergeReplicaItems(both, again)
+	require.NoError(t, err)
+	assert.Equal(t, "zxy", replicaIDs(merged))
+	merged, err = MergeReplicaItems(merged, moveUp)
+	require.NoError(t, err)
+	assert.Equal(t, "zxy", replicaIDs(merged))
+}
+
+func TestMergeReplicaItems_Delete(t *testing.T) {
+	base := []ReplicaItem[string]{
+		{ID: "x", Rank: mustReplicaRank(t, "0|100000:")},
+		{ID: "y", Rank: mustReplicaRank(t, "0|100001:")},
+	}
+	deleted := []ReplicaItem[string]{
+		base[0],
+		{ID: "y", Rank: base[1].Rank, Version: NextReplicaVersion(base, "a"), Deleted: true},
+	}
+	merged, err := MergeReplicaItems(base, deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "x", replicaIDs(merged))
+	require.Len(t, merged, 2)
+	assert.True(t, merged[1].Deleted)
+	merged, err = MergeReplicaItems(merged, base)
+	require.NoError(t, err)
+	assert.Equal(t, "x", replicaIDs(merged))
+
+	reused := []ReplicaItem[string]{{ID: "z", Rank: base[1].Rank, Version: NextReplicaVersion(merged, "a")}}
+	merged, err = MergeReplicaItems(merged, reused)
+	require.NoError(t, err)
+	assert.Equal(t, "xz", replicaIDs(merged))
+
+	_, err = MergeReplicaItems(base, []ReplicaItem[string]{{ID: "y", Rank: base[0].Rank}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+// Package simulate runs insertion workloads against LexoRank and reports how
+// the ranks grow, to help choose length limits and step strategies.
+package simulate
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	lexorank "github.com/LEXASOFT/LexoRank"
+)
+
+type Workload string
+
+const (
+	Random    Workload = "random"
+	Append    Workload = "append"
+	Prepend   Workload = "prepend"
+	HotSpot   Workload = "hotspot"
+	DragToTop Workload = "drag-to-top"
+)
+
+var Workloads = []Workload{Random, Append, Prepend, HotSpot, DragToTop}
+
+type Strategy string
+
+const (
+	// Between places items at the ends between the edge item and the bucket bound.
+	Between Strategy = "between"
+	// Step places items at the ends with Next and Prev of the edge item.
+	Step Strategy = "step"
+)
+
+var Strategies = []Strategy{Between, Step}
+
+type Config struct {
+	Workload  Workload
+	Strategy  Strategy
+	Initial   int
+	Inserts   int
+	MaxLength int
+	Density   float64
+	Seed      int64
+}
+
+type Result struct {
+	Config          Config
+	Lengths         []int
+	ScaleP50        int
+	ScaleP99        int
+	ScaleMax        int
+	Rebalances      int
+	RebalancedRanks int
+}
+
+func Run(cfg Config) (*Result, error) {
+	switch cfg.Strategy {
+	case Between, Step:
+	default:
+		return nil, fmt.Errorf("unknown strategy: %q", cfg.Strategy)
+	}
+	if cfg.Density == 0 {
+		cfg.Density = 0.5
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	list, err := lexorank.MinLexoRank.BetweenN(lexorank.MaxLexoRank, cfg.Initial)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Config: cfg}
+	var scales []int
+	sample := cfg.Inserts / 10
+	if sample == 0 {
+		sample = 1
+	}
+	for step := 0; step < cfg.Inserts; step++ {
+		var idx int
+		switch cfg.Workload {
+		case Random:
+			idx = rng.Intn(len(list) + 1)
+		case Append:
+			idx = len(list)
+		case Prepend:
+			idx = 0
+		case HotSpot:
+			idx = 1
+			if len(list) == 0 {
+				idx = 0
+			}
+		case DragToTop:
+			if len(list) > 0 {
+				from := rng.Intn(len(list))
+				list = append(list[:from], list[from+1:]...)
+			}
+			idx = 0
+		default:
+			return nil, fmt.Errorf("unknown workload: %q", cfg.Workload)
+		}
+		rank, err := place(list, idx, cfg.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("insert %d: %w", step, err)
+		}
+		list = append(list, nil)
+		copy(list[idx+1:], list[idx:])
+		list[idx] = rank
+		scales = append(scales, scale(rank))
+
+		if cfg.MaxLength > 0 && len(rank.String()) > cfg.MaxLength {
+			windows, err := lexorank.RebalanceWindows(list, cfg.MaxLength, cfg.Density)
+			if err != nil {
+				return nil, fmt.Errorf("rebalance after insert %d: %w", step, err)
+			}
+			for _, window := range windows {
+				copy(list[window.Start:window.End], window.Ranks)
+				result.RebalancedRanks += len(window.Ranks)
+			}
+			result.Rebalances++
+		}
+		if (step+1)%sample == 0 {
+			result.Lengths = append(result.Lengths, maxLength(list))
+		}
+	}
+	if len(scales) > 0 {
+		sort.Ints(scales)
+		result.ScaleP50 = scales[(len(scales)-1)*50/100]
+		result.ScaleP99 = scales[(len(scales)-1)*99/100]
+		result.ScaleMax = scales[len(scales)-1]
+	}
+	return result, nil
+}
+
+// Compare runs cfg once with every strategy.
+func Compare(cfg Config) ([]*Result, error) {
+	results := make([]*Result, 0, len(Strategies))
+	for _, strategy := range Strategies {
+		cfg.Strategy = strategy
+		result, err := Run(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strategy, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func place(list []*lexorank.LexoRank, idx int, strategy Strategy) (*lexorank.LexoRank, error) {
+	switch {
+	case len(list) == 0:
+		return lexorank.MidLexoRank, nil
+	case idx == 0 && strategy == Step:
+		return list[0].Prev()
+	case idx == 0:
+		return lexorank.MinLexoRank.Between(list[0])
+	case idx == len(list) && strategy == Step:
+		return list[idx-1].Next()
+	case idx == len(list):
+		return list[idx-1].Between(lexorank.MaxLexoRank)
+	}
+	return list[idx-1].Between(list[idx])
+}
+
+func scale(rank *lexorank.LexoRank) int {
+	value := rank.String()
+	return len(value) - strings.IndexByte(value, ':') - 1
+}
+
+func maxLength(list []*lexorank.LexoRank) int {
+	longest := 0
+	for _, rank := range list {
+		if length := len(rank.String()); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
@@ -0,0 +1,52 @@
+package simulate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	for _, workload := range Workloads {
+		for _, strategy := range Strategies {
+			t.Run(string(workload)+"/"+string(strategy), func(t *testing.T) {
+				got, err := Run(Config{Workload: workload, Strategy: strategy, Initial: 10, Inserts: 200, MaxLength: 14, Seed: 1})
+				assert.NoError(t, err)
+				assert.Len(t, got.Lengths, 10)
+				assert.LessOrEqual(t, got.ScaleP50, got.ScaleP99)
+				assert.LessOrEqual(t, got.ScaleP99, got.ScaleMax)
+				for _, length := range got.Lengths {
+					assert.LessOrEqual(t, length, 14)
+				}
+			})
+		}
+	}
+}
+
+func TestRun_HotSpot(t *testing.T) {
+	got, err := Run(Config{Workload: HotSpot, Strategy: Between, Initial: 2, Inserts: 100})
+	assert.NoError(t, err)
+	assert.Zero(t, got.Rebalances)
+	assert.Greater(t, got.ScaleMax, 10)
+
+	got, err = Run(Config{Workload: HotSpot, Strategy: Between, Initial: 2, Inserts: 100, MaxLength: 12})
+	assert.NoError(t, err)
+	assert.NotZero(t, got.Rebalances)
+	assert.LessOrEqual(t, got.ScaleMax, 4)
+}
+
+func TestCompare(t *testing.T) {
+	got, err := Compare(Config{Workload: Append, Inserts: 50})
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, Between, got[0].Config.Strategy)
+	assert.Equal(t, Step, got[1].Config.Strategy)
+	assert.Less(t, got[1].ScaleMax, got[0].ScaleMax)
+}
+
+func TestRun_Unknown(t *testing.T) {
+	_, err := Run(Config{Workload: Random, Strategy: "foo", Inserts: 1})
+	assert.EqualError(t, err, `unknown strategy: "foo"`)
+	_, err = Run(Config{Workload: "foo", Strategy: Step, Inserts: 1})
+	assert.EqualError(t, err, `unknown workload: "foo"`)
+}
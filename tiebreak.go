@@ -0,0 +1,70 @@
+package lexorank
+
+import (
+	"fmt"
+)
+
+// ordered matches the types that support <, for ordering items by ID.
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// BetweenItems returns a rank for an item placed at index among neighbors,
+// even when the items on either side share a rank, together with new ranks for
+// the fewest neighbors needed to make the order strict again.
+//
+// neighbors is a contiguous part of the list in order of rank, then ID, and
+// must not contain the placed item. It must hold every item that shares a rank
+// with neighbors[index-1] or neighbors[index] and, unless the list ends there,
+// one item with a smaller and one with a larger rank; those outer items keep
+// their ranks.
+func BetweenItems[K ordered](neighbors []RankedItem[K], index int) (*LexoRank, []RankedItem[K], error) {
+	if index < 0 || index > len(neighbors) {
+		return nil, nil, fmt.Errorf("index %d out of range [0, %d]", index, len(neighbors))
+	}
+	bucket := LexoRankBucket0
+	for idx, item := range neighbors {
+		if item.Rank == nil {
+			return nil, nil, fmt.Errorf("id %v has no rank", item.ID)
+		}
+		if idx == 0 {
+			bucket = item.Rank.bucket
+			continue
+		}
+		prev := neighbors[idx-1]
+		if !item.Rank.bucket.Equals(bucket) {
+			return nil, nil, RanksMixedBucketErr
+		}
+		if cmp := prev.Rank.Compare(item.Rank); cmp > 0 || cmp == 0 && prev.ID >= item.ID {
+			return nil, nil, fmt.Errorf("%w: %v at %s before %v at %s", RanksNotSortedErr, prev.ID, prev.Rank, item.ID, item.Rank)
+		}
+	}
+
+	ranks := make([]*LexoRank, 0, len(neighbors)+1)
+	for _, item := range neighbors[:index] {
+		ranks = append(ranks, item.Rank)
+	}
+	ranks = append(ranks, nil)
+	for _, item := range neighbors[index:] {
+		ranks = append(ranks, item.Rank)
+	}
+	planned, err := planRanks(ranks, bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updates []RankedItem[K]
+	for idx, rank := range planned {
+		switch {
+		case rank == nil || idx == index:
+			continue
+		case idx < index:
+			updates = append(updates, RankedItem[K]{ID: neighbors[idx].ID, Rank: rank})
+		default:
+			updates = append(updates, RankedItem[K]{ID: neighbors[idx-1].ID, Rank: rank})
+		}
+	}
+	return planned[index], updates, nil
+}
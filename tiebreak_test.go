@@ -0,0 +1,99 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rankedItems(t *testing.T, pairs ...string) []RankedItem[string] {
+	items := make([]RankedItem[string], 0, len(pairs)/2)
+	for idx := 0; idx < len(pairs); idx += 2 {
+		items = append(items, RankedItem[string]{ID: pairs[idx], Rank: parseRanks(t, pairs[idx+1])[0]})
+	}
+	return items
+}
+
+func TestBetweenItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		neighbors   []string
+		index       int
+		want        string
+		wantUpdates map[string]string
+	}{
+		{
+			name:      "distinct",
+			neighbors: []string{"a", "0|100000:", "b", "0|100001:"},
+			index:     1,
+			want:      "0|100000:i",
+		},
+		{
+			name:        "equal neighbors",
+			neighbors:   []string{"a", "0|100000:", "b", "0|100001:", "c", "0|100001:", "d", "0|100002:"},
+			index:       2,
+			want:        "0|100001:c",
+			wantUpdates: map[string]string{"c": "0|100001:o"},
+		},
+		{
+			name:        "run of three",
+			neighbors:   []string{"a", "0|100000:", "b", "0|100000:", "c", "0|100000:"},
+			index:       1,
+			want:        "0|9qzzzz:",
+			wantUpdates: map[string]string{"b": "0|ihzzzz:", "c": "0|r8zzzz:"},
+		},
+		{
+			name:      "empty",
+			neighbors: nil,
+			index:     0,
+			want:      "0|hzzzzz:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			neighbors := rankedItems(t, tt.neighbors...)
+			rank, updates, err := BetweenItems(neighbors, tt.index)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rank.String())
+			got := map[string]string{}
+			for _, update := range updates {
+				got[update.ID] = update.Rank.String()
+			}
+			if tt.wantUpdates == nil {
+				tt.wantUpdates = map[string]string{}
+			}
+			assert.Equal(t, tt.wantUpdates, got)
+
+			final := map[string]*LexoRank{"moved": rank}
+			for _, item := range neighbors {
+				final[item.ID] = item.Rank
+			}
+			for _, update := range updates {
+				final[update.ID] = update.Rank
+			}
+			order := make([]string, 0, len(neighbors)+1)
+			for _, item := range neighbors[:tt.index] {
+				order = append(order, item.ID)
+			}
+			order = append(order, "moved")
+			for _, item := range neighbors[tt.index:] {
+				order = append(order, item.ID)
+			}
+			for idx := 1; idx < len(order); idx++ {
+				assert.Equal(t, -1, final[order[idx-1]].Compare(final[order[idx]]), "%s before %s", order[idx-1], order[idx])
+			}
+		})
+	}
+}
+
+func TestBetweenItems_Errors(t *testing.T) {
+	_, _, err := BetweenItems(rankedItems(t, "b", "0|100000:", "a", "0|100000:"), 1)
+	assert.ErrorIs(t, err, RanksNotSortedErr)
+	_, _, err = BetweenItems(rankedItems(t, "a", "0|100001:", "b", "0|100000:"), 1)
+	assert.ErrorIs(t, err, RanksNotSortedErr)
+	_, _, err = BetweenItems(rankedItems(t, "a", "0|100000:", "b", "1|100001:"), 1)
+	assert.ErrorIs(t, err, RanksMixedBucketErr)
+	_, _, err = BetweenItems(rankedItems(t, "a", "0|100000:"), 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package lexorank
+
+import (
+	"fmt"
+	"strings"
+)
+
+var _ KeyEncoding = (*UnboundedLexoRankEncoding)(nil)
+
+// UnboundedLexoRankEncoding produces "bucket|<head><integer>:<fraction>" keys
+// whose integer part has no fixed width, so Next and Prev never run into
+// MaxLexoRank or MinLexoRank.
+//
+// The head encodes the sign and digit count of the integer part so that keys
+// sort as bytes: a non-negative integer of k digits gets (k-1)/25 'z' followed
+// by 'a'+(k-1)%25; a negative one gets (k-1)/9 '0' followed by '9'-(k-1)%9 and
+// its digits complemented. The fraction is always the non-negative distance
+// from the integer part.
+type UnboundedLexoRankEncoding struct {
+	Bucket *LexoRankBucket
+}
+
+func NewUnboundedLexoRankEncoding(bucket *LexoRankBucket) *UnboundedLexoRankEncoding {
+	return &UnboundedLexoRankEncoding{Bucket: bucket}
+}
+
+func (e *UnboundedLexoRankEncoding) Between(prev, next string) (string, error) {
+	var low, high *LexoDecimal
+	var err error
+	if prev != "" {
+		if low, err = e.parse(prev); err != nil {
+			return "", err
+		}
+	}
+	if next != "" {
+		if high, err = e.parse(next); err != nil {
+			return "", err
+		}
+	}
+	switch {
+	case low == nil && high == nil:
+		return e.format(zeroDecimal), nil
+	case low == nil:
+		return e.format(LexoDecimalMake(unboundedFloor(high), 0).Sub(eightDecimal)), nil
+	case high == nil:
+		return e.format(LexoDecimalMake(unboundedCeil(low), 0).Add(eightDecimal)), nil
+	case prev >= next:
+		return "", fmt.Errorf("%q is not less than %q", prev, next)
+	}
+	shift := zeroDecimal
+	if floor := unboundedFloor(low); floor.sign < 0 {
+		shift = LexoDecimalMake(floor.negate(), 0)
+	}
+	mid, err := low.Add(shift).Between(high.Add(shift))
+	if err != nil {
+		return "", err
+	}
+	return e.format(mid.Sub(shift)), nil
+}
+
+func (e *UnboundedLexoRankEncoding) Validate(key string) error {
+	_, err := e.parse(key)
+	return err
+}
+
+func (e *UnboundedLexoRankEncoding) format(value *LexoDecimal) string {
+	integer := unboundedFloor(value)
+	fraction := value.Sub(LexoDecimalMake(integer, 0))
+
+	var sb strings.Builder
+	sb.WriteString(e.Bucket.String())
+	sb.WriteByte('|')
+	digits := integer.String()
+	if integer.sign < 0 {
+		digits = digits[1:]
+		sb.WriteString(strings.Repeat("0", (len(digits)-1)/9))
+		sb.WriteByte(byte('9' - (len(digits)-1)%9))
+		for idx := 0; idx < len(digits); idx++ {
+			digit, _ := LexoRankSystem.Digit(digits[idx])
+			sb.WriteByte(LexoRankSystem.Char(LexoRankSystem.GetBase() - 1 - digit))
+		}
+	} else {
+		sb.WriteString(strings.Repeat("z", (len(digits)-1)/25))
+		sb.WriteByte(byte('a' + (len(digits)-1)%25))
+		sb.WriteString(digits)
+	}
+	sb.WriteByte(LexoRankSystem.GetRadixPointChar())
+	if scale := fraction.GetScale(); scale > 0 {
+		str := fraction.mag.String()
+		sb.WriteString(strings.Repeat("0", scale-len(str)))
+		sb.WriteString(str)
+	}
+	return sb.String()
+}
+
+func (e *UnboundedLexoRankEncoding) parse(key string) (*LexoDecimal, error) {
+	prefix := e.Bucket.String() + "|"
+	if !strings.HasPrefix(key, prefix) {
+		return nil, fmt.Errorf("key %q outside bucket %s", key, e.Bucket)
+	}
+	rest := key[len(prefix):]
+	radix := strings.IndexByte(rest, LexoRankSystem.GetRadixPointChar())
+	if radix < 0 {
+		return nil, fmt.Errorf("key %q has no %q", key, LexoRankSystem.GetRadixPointChar())
+	}
+	head, fraction := rest[:radix], rest[radix+1:]
+	negative := head != "" && head[0] <= '9'
+	length, fill, step := 1, byte('z'), 25
+	if negative {
+		fill, step = '0', 9
+	}
+	for ; head != "" && head[0] == fill; head = head[1:] {
+		length += step
+	}
+	switch {
+	case head == "":
+		return nil, fmt.Errorf("key %q has no integer head", key)
+	case negative && head[0] >= '1' && head[0] <= '9':
+		length += int('9' - head[0])
+	case !negative && head[0] >= 'a' && head[0] <= 'y':
+		length += int(head[0] - 'a')
+	default:
+		return nil, fmt.Errorf("key %q has invalid integer head %q", key, head[0])
+	}
+	digits := head[1:]
+	if len(digits) != length {
+		return nil, fmt.Errorf("key %q: integer has %d digits, head says %d", key, len(digits), length)
+	}
+	if negative {
+		complement := make([]byte, len(digits))
+		for idx := 0; idx < len(digits); idx++ {
+			digit, err := LexoRankSystem.Digit(digits[idx])
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", key, err)
+			}
+			complement[idx] = LexoRankSystem.Char(LexoRankSystem.GetBase() - 1 - digit)
+		}
+		digits = string(LexoRankSystem.GetNegativeChar()) + string(complement)
+	}
+	integer, err := LexoIntegerParse(digits, LexoRankSystem)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", key, err)
+	}
+	fractionValue, err := LexoDecimalParse(string(LexoRankSystem.Char(0))+string(LexoRankSystem.GetRadixPointChar())+fraction, LexoRankSystem)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", key, err)
+	}
+	value := LexoDecimalMake(integer, 0).Add(fractionValue)
+	if canonical := e.format(value); canonical != key {
+		return nil, fmt.Errorf("key %q not canonical, expected %s", key, canonical)
+	}
+	return value, nil
+}
+
+func unboundedFloor(value *LexoDecimal) *LexoInteger {
+	if value.mag.sign >= 0 {
+		return value.Floor()
+	}
+	ceil := LexoDecimalMake(value.mag.negate(), value.scale).Ceil()
+	return ceil.negate()
+}
+
+func unboundedCeil(value *LexoDecimal) *LexoInteger {
+	if value.mag.sign >= 0 {
+		return value.Ceil()
+	}
+	floor := LexoDecimalMake(value.mag.negate(), value.scale).Floor()
+	return floor.negate()
+}
@@ -0,0 +1,88 @@
+package lexorank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnboundedLexoRankEncoding_Between(t *testing.T) {
+	tests := []struct {
+		prev    string
+		next    string
+		want    string
+		wantErr bool
+	}{
+		{prev: "", next: "", want: "0|a0:"},
+		{prev: "0|a0:", next: "", want: "0|a8:"},
+		{prev: "", next: "0|a0:", want: "0|9r:"},
+		{prev: "0|a0:", next: "0|a1:", want: "0|a0:i"},
+		{prev: "0|9r:", next: "0|9s:", want: "0|9r:i"},
+		{prev: "0|9y:", next: "0|a0:", want: "0|9y:i"},
+		{prev: "0|9y:i", next: "", want: "0|a8:"},
+		{prev: "", next: "0|9y:i", want: "0|9q:"},
+		{prev: "0|8yz:", next: "0|90:", want: "0|8yz:i"},
+		{prev: "0|yzzzzzzzzzzzzzzzzzzzzzzzzz:", next: "", want: "0|za10000000000000000000000007:"},
+		{prev: "", next: "0|1000000000:", want: "0|09yzzzzzzzzs:"},
+		{prev: "0|a1:", next: "0|a0:", wantErr: true},
+		{prev: "0|a0:", next: "0|a0:", wantErr: true},
+		{prev: "0|a00:", next: "", wantErr: true},
+		{prev: "0|b00:", next: "", wantErr: true},
+		{prev: "0|a0:0", next: "", wantErr: true},
+		{prev: "0|9:", next: "", wantErr: true},
+		{prev: "0|a:", next: "", wantErr: true},
+		{prev: "1|a0:", next: "", wantErr: true},
+		{prev: "0|a0", next: "", wantErr: true},
+	}
+	encoding := NewUnboundedLexoRankEncoding(LexoRankBucket0)
+	for _, tt := range tests {
+		t.Run(tt.prev+"_"+tt.next, func(t *testing.T) {
+			got, err := encoding.Between(tt.prev, tt.next)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.want, got, "Between(%q, %q)", tt.prev, tt.next)
+		})
+	}
+}
+
+func TestUnboundedLexoRankEncoding_Grow(t *testing.T) {
+	encoding := NewUnboundedLexoRankEncoding(LexoRankBucket0)
+	keys := []string{}
+	first, _ := encoding.Between("", "")
+	for _, dir := range []int{1, -1} {
+		key := first
+		for idx := 0; idx < 3000; idx++ {
+			var next string
+			var err error
+			if dir > 0 {
+				next, err = encoding.Between(key, "")
+				assert.Less(t, key, next)
+			} else {
+				next, err = encoding.Between("", key)
+				assert.Less(t, next, key)
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, encoding.Validate(next))
+			keys = append(keys, next)
+			key = next
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	sort.Strings(keys)
+	for idx := 0; idx < 2000; idx++ {
+		i := rnd.Intn(len(keys) - 1)
+		mid, err := encoding.Between(keys[i], keys[i+1])
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, encoding.Validate(mid))
+		assert.Less(t, keys[i], mid)
+		assert.Less(t, mid, keys[i+1])
+		keys = append(keys[:i+1], append([]string{mid}, keys[i+1:]...)...)
+	}
+}
@@ -0,0 +1,100 @@
+package lexorank
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// weightResolution is the integer weight GapWeights gives the densest gap.
+const weightResolution = 1000
+
+// GapWeights samples density at the middle of each of the n+1 gaps around n
+// evenly spaced ranks, position 0 being the start of the bucket and 1 its end,
+// and scales the samples to integer weights for RebalanceWeighted. Every gap
+// gets a weight of at least 1, so no gap closes entirely.
+func GapWeights(n int, density func(position float64) float64) ([]int64, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative count: %d", n)
+	}
+	samples := make([]float64, n+1)
+	peak := 0.0
+	for idx := range samples {
+		sample := density((float64(idx) + 0.5) / float64(n+1))
+		if sample < 0 || math.IsNaN(sample) || math.IsInf(sample, 0) {
+			return nil, fmt.Errorf("invalid density %v at gap %d", sample, idx)
+		}
+		samples[idx] = sample
+		peak = math.Max(peak, sample)
+	}
+	if peak == 0 {
+		return nil, fmt.Errorf("density is zero everywhere")
+	}
+	weights := make([]int64, n+1)
+	for idx, sample := range samples {
+		weights[idx] = int64(math.Max(1, math.Round(sample/peak*weightResolution)))
+	}
+	return weights, nil
+}
+
+// HistoryWeights counts past inserts into each of the len(ranks)+1 gaps around
+// sorted ranks, plus one for every gap. An insert counts towards the gap below
+// the first rank that is not less than it, so the rank of an item already in
+// ranks counts towards the gap just before that item.
+func HistoryWeights(ranks, inserts []*LexoRank) []int64 {
+	weights := make([]int64, len(ranks)+1)
+	for idx := range weights {
+		weights[idx] = 1
+	}
+	for _, insert := range inserts {
+		if insert == nil {
+			continue
+		}
+		gap := sort.Search(len(ranks), func(idx int) bool {
+			return ranks[idx].Compare(insert) >= 0
+		})
+		weights[gap]++
+	}
+	return weights
+}
+
+// RebalanceWeighted respaces sorted ranks over their whole bucket so that the
+// gaps before, between and after them are proportional to weights, which has
+// one entry per gap, len(ranks)+1 in all. Leaving wide gaps where inserts are
+// expected keeps those inserts short for longer than even spacing does. With
+// equal weights the result matches BetweenN across the bucket.
+func RebalanceWeighted(ranks []*LexoRank, weights []int64, maxLength int) ([]*LexoRank, error) {
+	if len(weights) != len(ranks)+1 {
+		return nil, fmt.Errorf("%d weights for %d gaps", len(weights), len(ranks)+1)
+	}
+	var total int64
+	for idx, weight := range weights {
+		if weight <= 0 {
+			return nil, fmt.Errorf("non-positive weight %d at gap %d", weight, idx)
+		}
+		if total += weight; total > maxSpreadWeight {
+			return nil, fmt.Errorf("total weight above %d", int64(maxSpreadWeight))
+		}
+	}
+	if len(ranks) == 0 {
+		return nil, nil
+	}
+	bucket, err := checkAscending(ranks)
+	if err != nil {
+		return nil, err
+	}
+	scale := maxScale(bucket, maxLength)
+	if scale < 0 {
+		return nil, fmt.Errorf("max length too small: %d", maxLength)
+	}
+	low, high := minLexoRank(bucket).decimal, maxLexoRank(bucket).decimal
+	spread := newWeightedDecimalSpread(low, high, weights, 1)
+	if spread.scale > scale {
+		return nil, fmt.Errorf("%d ranks with these weights do not fit into scale %d", len(ranks), scale)
+	}
+	result := make([]*LexoRank, len(ranks))
+	for idx := range result {
+		result[idx] = NewLexoRank(bucket, spread.at(idx))
+	}
+	return result, nil
+}
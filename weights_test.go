@@ -0,0 +1,70 @@
+package lexorank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGapWeights(t *testing.T) {
+	weights, err := GapWeights(3, func(position float64) float64 { return 1 - position })
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1000, 714, 429, 143}, weights)
+
+	weights, err = GapWeights(2, func(position float64) float64 {
+		if position < 0.5 {
+			return 1
+		}
+		return 0
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1000, 1, 1}, weights)
+
+	_, err = GapWeights(2, func(float64) float64 { return 0 })
+	assert.Error(t, err)
+	_, err = GapWeights(2, func(float64) float64 { return -1 })
+	assert.Error(t, err)
+	_, err = GapWeights(-1, func(float64) float64 { return 1 })
+	assert.Error(t, err)
+}
+
+func TestHistoryWeights(t *testing.T) {
+	ranks := parseRanks(t, "0|100000:", "0|200000:", "0|300000:")
+	inserts := parseRanks(t, "0|000001:", "0|100000:", "0|150000:", "0|400000:")
+	assert.Equal(t, []int64{3, 2, 1, 2}, HistoryWeights(ranks, append(inserts, nil)))
+	assert.Equal(t, []int64{1}, HistoryWeights(nil, nil))
+}
+
+func TestRebalanceWeighted(t *testing.T) {
+	ranks := parseRanks(t, "0|100000:", "0|100000:i", "0|100000:r")
+
+	even, err := RebalanceWeighted(ranks, []int64{1, 1, 1, 1}, 10)
+	require.NoError(t, err)
+	want, err := MinLexoRank.BetweenN(MaxLexoRank, 3)
+	require.NoError(t, err)
+	assert.Equal(t, want, even)
+
+	top, err := RebalanceWeighted(ranks, []int64{9, 1, 1, 1}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, parseRanks(t, "0|qzzzzz:", "0|tzzzzz:", "0|wzzzzz:"), top)
+
+	uneven, err := RebalanceWeighted(ranks[:2], []int64{1, 10000000000, 1}, 16)
+	require.NoError(t, err)
+	assert.Equal(t, parseRanks(t, "0|000000:7", "0|zzzzzy:s"), uneven)
+
+	_, err = RebalanceWeighted(ranks[:2], []int64{1, 10000000000, 1}, 9)
+	assert.Error(t, err)
+	_, err = RebalanceWeighted(ranks, []int64{1, 1, 1}, 10)
+	assert.Error(t, err)
+	_, err = RebalanceWeighted(ranks, []int64{1, 0, 1, 1}, 10)
+	assert.Error(t, err)
+	_, err = RebalanceWeighted(ranks[1:], []int64{maxSpreadWeight, 1, 1}, 10)
+	assert.Error(t, err)
+	_, err = RebalanceWeighted([]*LexoRank{ranks[1], ranks[0]}, []int64{1, 1, 1}, 10)
+	assert.ErrorIs(t, err, RanksNotSortedErr)
+
+	none, err := RebalanceWeighted(nil, []int64{1}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}